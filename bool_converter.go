@@ -2,6 +2,7 @@ package generator
 
 import (
 	"reflect"
+	"strconv"
 
 	"github.com/dave/jennifer/jen"
 )
@@ -11,18 +12,18 @@ type BoolConverter struct{}
 
 var _ AttributeConverter = &BoolConverter{}
 
-func (c *BoolConverter) Check(typ reflect.Type) (bool, error) {
+func (c *BoolConverter) Check(typ GoType) (bool, error) {
 	if typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
 	}
 	return typ.Kind() == reflect.Bool, nil
 }
 
-func (c *BoolConverter) GetFrameworkType(_ *Converter, typ reflect.Type) (*jen.Statement, error) {
+func (c *BoolConverter) GetFrameworkType(_ *Converter, typ GoType) (*jen.Statement, error) {
 	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "Bool"), nil
 }
 
-func (c *BoolConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *BoolConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	method := "ValueBool"
 	if typ.Kind() == reflect.Pointer {
 		method = "ValueBoolPointer"
@@ -31,7 +32,7 @@ func (c *BoolConverter) Decode(converters *Converter, field *FieldInformation, p
 	return decode(src, target.Op("=").Add(src.Clone()).Dot(method).Call())
 }
 
-func (c *BoolConverter) Encode(converters *Converter, field *FieldInformation, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *BoolConverter) Encode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	method := "BoolValue"
 	if typ.Kind() == reflect.Pointer {
 		method = "BoolPointerValue"
@@ -46,3 +47,48 @@ func (c *BoolConverter) GetSchema(converters *Converter, path string, info *Fiel
 func (c *BoolConverter) GetType() *jen.Statement {
 	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "BoolType")
 }
+
+func (c *BoolConverter) GetAttrType(_ *Converter, _ *FieldInformation, _ GoType) (*jen.Statement, error) {
+	return c.GetType(), nil
+}
+
+func (c *BoolConverter) GetValue(_ *Converter, _ *FieldInformation, _ GoType, value interface{}) (*jen.Statement, error) {
+	if value == nil {
+		return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "BoolNull").Call(), nil
+	}
+	b, err := parseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "BoolValue").Call(jen.Lit(b)), nil
+}
+
+func (c *BoolConverter) GetDefault(_ *Converter, _ *FieldInformation, literal string) (*jen.Statement, error) {
+	b, err := parseBool(literal)
+	if err != nil {
+		return nil, err
+	}
+	return jen.Qual(resourceSchemaImportPath+"booldefault", "StaticBool").Call(jen.Lit(b)), nil
+}
+
+func parseBool(value interface{}) (bool, error) {
+	if b, ok := value.(bool); ok {
+		return b, nil
+	}
+	literal, err := scalarLiteral(value)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(literal)
+}
+
+func (c *BoolConverter) GetAttributeKind(_ *FieldInformation) string {
+	return "Bool"
+}
+
+func (c *BoolConverter) GetValidators(_ *Converter, _ *FieldInformation, constraints []Constraint) ([]jen.Code, error) {
+	if len(constraints) != 0 {
+		return nil, unsupportedConstraint(constraints[0], "Bool")
+	}
+	return nil, nil
+}
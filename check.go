@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StaleFile is a generated file whose content on disk differs from the one
+// that would be rendered
+type StaleFile struct {
+	Path string
+	// Diff is the unified diff between the file on disk and the rendered one
+	Diff string
+}
+
+// StaleFilesError is returned in check mode, see GeneratorOptions.Check, when
+// some of the generated files are not up to date
+type StaleFilesError struct {
+	Files []StaleFile
+}
+
+func (e *StaleFilesError) Error() string {
+	var b strings.Builder
+	b.WriteString("the generated files are not up to date:")
+	for _, f := range e.Files {
+		fmt.Fprintf(&b, "\n  - %s", f.Path)
+	}
+	for _, f := range e.Files {
+		b.WriteString("\n\n")
+		b.WriteString(f.Diff)
+	}
+	return b.String()
+}
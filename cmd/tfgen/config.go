@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config lists the generators to render, the objects are given as the import
+// path of their package followed by the name of the type, e.g.
+// github.com/YourProject/api.Coffee
+type Config struct {
+	Options     Options    `yaml:"options"`
+	Models      *Generator `yaml:"models"`
+	Resources   *Generator `yaml:"resources"`
+	DataSources *Generator `yaml:"data_sources"`
+	Provider    *Generator `yaml:"provider"`
+}
+
+type Options struct {
+	UseDocComments bool `yaml:"use_doc_comments"`
+	// FieldInformation is the tag used to get the information of the
+	// fields, it can be terraform, json or terraform+json to fallback on the
+	// json tag when the terraform tag is missing
+	FieldInformation string `yaml:"field_information"`
+	// SplitFiles renders each object in its own files
+	SplitFiles bool `yaml:"split_files"`
+	// Check compares the generated files with the ones on disk instead of
+	// writing them, it is set with the -check flag
+	Check bool `yaml:"-"`
+}
+
+type Generator struct {
+	Package string            `yaml:"package"`
+	Path    string            `yaml:"path"`
+	Objects map[string]string `yaml:"objects"`
+	// ListDataSources can only be used for the models and the data sources
+	ListDataSources map[string]string `yaml:"list_data_sources"`
+	// FromResource derives the data sources from the types tagged for the
+	// resources
+	FromResource bool `yaml:"from_resource"`
+}
+
+// TypeReference is a Go type given in the configuration
+type TypeReference struct {
+	ImportPath string
+	Name       string
+}
+
+// LoadConfig reads the configuration at path, the paths of the generators are
+// made absolute using the directory of the configuration
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var config Config
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	if err := config.validate(dir); err != nil {
+		return nil, fmt.Errorf("invalid configuration %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+func (c *Config) validate(dir string) error {
+	switch c.Options.FieldInformation {
+	case "":
+		c.Options.FieldInformation = "terraform"
+	case "terraform", "json", "terraform+json":
+	default:
+		return fmt.Errorf("unknown field_information %q, expected terraform, json or terraform+json", c.Options.FieldInformation)
+	}
+
+	generators := c.generators()
+	if len(generators) == 0 {
+		return fmt.Errorf("no generator has been configured")
+	}
+	for name, g := range generators {
+		if g.Package == "" {
+			return fmt.Errorf("%s: the package is required", name)
+		}
+		if g.Path == "" {
+			return fmt.Errorf("%s: the path is required", name)
+		}
+		if !filepath.IsAbs(g.Path) {
+			g.Path = filepath.Join(dir, g.Path)
+		}
+		if len(g.Objects) == 0 && len(g.ListDataSources) == 0 {
+			return fmt.Errorf("%s: no object has been given", name)
+		}
+		if len(g.ListDataSources) != 0 && name != "models" && name != "data_sources" {
+			return fmt.Errorf("%s: list_data_sources can only be used for the models and the data sources", name)
+		}
+		if g.FromResource && name != "data_sources" {
+			return fmt.Errorf("%s: from_resource can only be used for the data sources", name)
+		}
+
+		for _, objects := range []map[string]string{g.Objects, g.ListDataSources} {
+			for key, ref := range objects {
+				if _, err := ParseTypeReference(ref); err != nil {
+					return fmt.Errorf("%s: %s: %w", name, key, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// generators returns the configured generators by the name of their section
+func (c *Config) generators() map[string]*Generator {
+	generators := map[string]*Generator{}
+	for name, g := range map[string]*Generator{
+		"models":       c.Models,
+		"resources":    c.Resources,
+		"data_sources": c.DataSources,
+		"provider":     c.Provider,
+	} {
+		if g != nil {
+			generators[name] = g
+		}
+	}
+	return generators
+}
+
+// ParseTypeReference parses a reference like github.com/YourProject/api.Coffee
+func ParseTypeReference(ref string) (TypeReference, error) {
+	i := strings.LastIndex(ref, ".")
+	if i <= strings.LastIndex(ref, "/") || i == len(ref)-1 || i == 0 {
+		return TypeReference{}, fmt.Errorf("invalid type %q, expected the import path of the package followed by the name of the type", ref)
+	}
+	return TypeReference{ImportPath: ref[:i], Name: ref[i+1:]}, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+const generatorImportPath = "github.com/Lenstra/terraform-plugin-generator"
+
+// RenderDriver renders the program calling the generators, it must import the
+// packages of the objects since the generator uses reflection on their types
+func RenderDriver(config *Config) *jen.File {
+	f := jen.NewFile("main")
+	f.HeaderComment("Code generated by tfgen; DO NOT EDIT.")
+
+	generators := []jen.Code{}
+	if g := config.Models; g != nil {
+		generators = append(generators, renderGenerator("ModelGenerator", nil, g, config.Options))
+	}
+	if g := config.Resources; g != nil {
+		generators = append(generators, renderGenerator("SchemaGenerator", jen.Qual(generatorImportPath, "ResourceSchema"), g, config.Options))
+	}
+	if g := config.DataSources; g != nil {
+		schemaType := "DataSourceSchema"
+		if g.FromResource {
+			schemaType = "DataSourceFromResource"
+		}
+		generators = append(generators, renderGenerator("SchemaGenerator", jen.Qual(generatorImportPath, schemaType), g, config.Options))
+	}
+	if g := config.Provider; g != nil {
+		generators = append(generators, renderGenerator("SchemaGenerator", jen.Qual(generatorImportPath, "ProviderSchema"), g, config.Options))
+	}
+
+	f.Func().Id("main").Params().Block(
+		jen.Err().Op(":=").Qual(generatorImportPath, "RenderAll").CallFunc(func(g *jen.Group) {
+			for _, code := range generators {
+				g.Line().Add(code)
+			}
+			g.Line()
+		}),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Qual("fmt", "Fprintln").Call(jen.Qual("os", "Stderr"), jen.Err()),
+			jen.Qual("os", "Exit").Call(jen.Lit(1)),
+		),
+	)
+	return f
+}
+
+func renderGenerator(kind string, schemaType *jen.Statement, g *Generator, options Options) *jen.Statement {
+	return jen.Op("&").Qual(generatorImportPath, kind).ValuesFunc(func(group *jen.Group) {
+		if schemaType != nil {
+			group.Line().Id("Type").Op(":").Add(schemaType)
+		}
+		group.Line().Id("Package").Op(":").Lit(g.Package)
+		group.Line().Id("Path").Op(":").Lit(g.Path)
+		group.Line().Id("Objects").Op(":").Add(renderObjects(g.Objects))
+		group.Line().Id("Options").Op(":").Op("&").Qual(generatorImportPath, "GeneratorOptions").ValuesFunc(func(group *jen.Group) {
+			group.Line().Id("GetFieldInformation").Op(":").Add(renderFieldInformationGetter(options.FieldInformation))
+			if options.UseDocComments {
+				group.Line().Id("UseDocComments").Op(":").True()
+			}
+			if options.SplitFiles {
+				group.Line().Id("SplitFiles").Op(":").True()
+			}
+			if options.Check {
+				group.Line().Id("Check").Op(":").True()
+			}
+			if len(g.ListDataSources) != 0 {
+				group.Line().Id("ListDataSources").Op(":").Add(renderObjects(g.ListDataSources))
+			}
+			group.Line()
+		})
+		group.Line()
+	})
+}
+
+func renderObjects(objects map[string]string) *jen.Statement {
+	return jen.Map(jen.String()).Interface().ValuesFunc(func(g *jen.Group) {
+		for _, key := range sortedKeys(objects) {
+			// The references have been checked when loading the configuration
+			ref, _ := ParseTypeReference(objects[key])
+			g.Line().Lit(key).Op(":").Qual(ref.ImportPath, ref.Name).Values()
+		}
+		g.Line()
+	})
+}
+
+func renderFieldInformationGetter(name string) *jen.Statement {
+	switch name {
+	case "json":
+		return jen.Qual(generatorImportPath, "GetFieldInformationFromJSONTag")
+	case "terraform+json":
+		return jen.Qual(generatorImportPath, "FallbackFieldInformationGetter").Call(
+			jen.Qual(generatorImportPath, "GetFieldInformationFromTerraformTag"),
+			jen.Qual(generatorImportPath, "GetFieldInformationFromJSONTag"),
+		)
+	}
+	return jen.Qual(generatorImportPath, "GetFieldInformationFromTerraformTag")
+}
+
+// RunDriver writes the driver in a temporary directory next to the
+// configuration, so that it belongs to the same module as the objects, and
+// runs it
+func RunDriver(config *Config, dir string) error {
+	tmp, err := os.MkdirTemp(dir, "_tfgen")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := RenderDriver(config).Save(filepath.Join(tmp, "main.go")); err != nil {
+		return fmt.Errorf("failed to write the driver: %w", err)
+	}
+
+	// The errors of the generators are written on stderr by the driver
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = tmp
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("the generation failed: %w\n%s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
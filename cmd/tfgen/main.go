@@ -0,0 +1,32 @@
+// tfgen renders the models and the schemas described by a configuration file,
+// it can be used in go:generate directives:
+//
+//	//go:generate go run github.com/Lenstra/terraform-plugin-generator/cmd/tfgen -config tfgen.yaml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	configPath := flag.String("config", "tfgen.yaml", "the path of the configuration file")
+	check := flag.Bool("check", false, "check that the generated files are up to date without writing them")
+	flag.Parse()
+
+	if err := run(*configPath, *check); err != nil {
+		fmt.Fprintf(os.Stderr, "tfgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string, check bool) error {
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	config.Options.Check = check
+	return RunDriver(config, filepath.Dir(configPath))
+}
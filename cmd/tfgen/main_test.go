@@ -0,0 +1,141 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, dir, config string) string {
+	path := filepath.Join(dir, "tfgen.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o644))
+	return path
+}
+
+// writeModule writes a module in a temporary directory that uses the
+// generator of this repository, it requires the same modules so that the
+// driver can be run without updating its go.mod
+func writeModule(t *testing.T) string {
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	require.NoError(t, err)
+	mod := strings.Replace(string(data), "module "+generatorImportPath, "module example.com/tfgen", 1)
+	mod += "\nrequire " + generatorImportPath + " v0.0.0\n\nreplace " + generatorImportPath + " => " + root + "\n"
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(mod), 0o644))
+	sum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.sum"), sum, 0o644))
+	return dir
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	config, err := LoadConfig(writeConfig(t, dir, `
+options:
+  use_doc_comments: true
+models:
+  package: models
+  path: ./models
+  objects:
+    Coffee: github.com/Lenstra/terraform-plugin-generator/tests/structs.Coffee
+  list_data_sources:
+    coffees: github.com/Lenstra/terraform-plugin-generator/tests/structs.Coffee
+`))
+	require.NoError(t, err)
+	require.Equal(t, "terraform", config.Options.FieldInformation)
+	require.Equal(t, filepath.Join(dir, "models"), config.Models.Path)
+
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"empty", "options: {}", "no generator has been configured"},
+		{"unknown field", "model: {}", "field model not found"},
+		{"package", "models: {path: ./models}", "models: the package is required"},
+		{"objects", "models: {path: ./models, package: models}", "models: no object has been given"},
+		{"type", "provider: {path: ./p, package: p, objects: {config: Config}}", `provider: config: invalid type "Config"`},
+		{"list", "resources: {path: ./r, package: r, list_data_sources: {coffees: api.Coffee}}", "resources: list_data_sources can only be used for the models and the data sources"},
+		{"from resource", "resources: {path: ./r, package: r, from_resource: true, objects: {coffee: api.Coffee}}", "resources: from_resource can only be used for the data sources"},
+		{"field information", "options: {field_information: xml}", `unknown field_information "xml"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfig(t, t.TempDir(), tt.config))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the driver is compiled with the go command")
+	}
+
+	// The driver must be in the module of the objects
+	dir := writeModule(t)
+
+	out := t.TempDir()
+	path := writeConfig(t, dir, `
+options:
+  field_information: terraform+json
+  split_files: true
+models:
+  package: models
+  path: `+filepath.Join(out, "models")+`
+  objects:
+    Coffee: github.com/Lenstra/terraform-plugin-generator/tests/structs.Coffee
+data_sources:
+  package: datasource
+  path: `+filepath.Join(out, "datasource")+`
+  from_resource: true
+  objects:
+    coffee: github.com/Lenstra/terraform-plugin-generator/tests/structs.Coffee
+  list_data_sources:
+    coffees: github.com/Lenstra/terraform-plugin-generator/tests/structs.Coffee
+`)
+	require.NoError(t, os.MkdirAll(filepath.Join(out, "models"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(out, "datasource"), 0o755))
+
+	require.NoError(t, run(path, false))
+	require.FileExists(t, filepath.Join(out, "models", "coffee_models.go"))
+	data, err := os.ReadFile(filepath.Join(out, "datasource", "coffees_schema.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "func coffeesSchema() schema.Schema {")
+
+	// The check mode reports the files that are not up to date
+	require.NoError(t, run(path, true))
+	stale := filepath.Join(out, "models", "coffee_models.go")
+	require.NoError(t, os.WriteFile(stale, []byte("package models\n"), 0o644))
+	err = run(path, true)
+	require.ErrorContains(t, err, "the generated files are not up to date:\n  - "+stale)
+	data, err = os.ReadFile(stale)
+	require.NoError(t, err)
+	require.Equal(t, "package models\n", string(data))
+
+	// The errors of the generators are reported with the path of the field
+	api := filepath.Join(dir, "api")
+	require.NoError(t, os.MkdirAll(api, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(api, "api.go"), []byte(`package api
+
+type Small struct {
+	Value int8 `+"`"+`terraform:"value,default=300"`+"`"+`
+}
+`), 0o644))
+	path = writeConfig(t, dir, `
+resources:
+  package: resource
+  path: `+filepath.Join(out, "resource")+`
+  objects:
+    small: example.com/tfgen/api.Small
+`)
+	err = run(path, false)
+	require.ErrorContains(t, err, "the generation failed")
+	require.ErrorContains(t, err, `small.value: invalid default value "300"`)
+}
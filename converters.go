@@ -2,7 +2,6 @@ package generator
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
@@ -26,6 +25,9 @@ func basicSchema(importPath, name string, info *FieldInformation, attributes []j
 		if info.Description != "" {
 			g.Line().Id("MarkdownDescription").Op(":").Lit(info.Description)
 		}
+		if info.DeprecationMessage != "" {
+			g.Line().Id("DeprecationMessage").Op(":").Lit(info.DeprecationMessage)
+		}
 		for _, code := range attributes {
 			g.Line().Add(code)
 		}
@@ -35,6 +37,9 @@ func basicSchema(importPath, name string, info *FieldInformation, attributes []j
 		if info.Validators != nil {
 			g.Line().Id("Validators").Op(":").Add(info.Validators)
 		}
+		if info.PlanModifiers != nil {
+			g.Line().Id("PlanModifiers").Op(":").Add(info.PlanModifiers)
+		}
 		g.Line()
 	}), nil, nil
 }
@@ -45,6 +50,7 @@ func decode(src *jen.Statement, inner *jen.Statement) (*jen.Statement, error) {
 
 var DefaultConverters = []AttributeConverter{
 	&MapInterfaceConverter{},
+	&NumberConverter{},
 	&BoolConverter{},
 	&StringConverter{},
 	&IntConverter{},
@@ -52,13 +58,14 @@ var DefaultConverters = []AttributeConverter{
 	&ListConverter{},
 	&MapConverter{},
 	&StructConverter{},
+	&DynamicConverter{},
 }
 
 type AttributeConverter interface {
-	Check(reflect.Type) (bool, error)
-	GetFrameworkType(*Converter, reflect.Type) (*jen.Statement, error)
-	Decode(*Converter, *FieldInformation, *jen.Statement, *jen.Statement, *jen.Statement, reflect.Type) (*jen.Statement, error)
-	Encode(*Converter, *FieldInformation, *jen.Statement, *jen.Statement, reflect.Type) (*jen.Statement, error)
+	Check(GoType) (bool, error)
+	GetFrameworkType(*Converter, GoType) (*jen.Statement, error)
+	Decode(*Converter, *FieldInformation, *jen.Statement, *jen.Statement, *jen.Statement, GoType) (*jen.Statement, error)
+	Encode(*Converter, *FieldInformation, *jen.Statement, *jen.Statement, *jen.Statement, GoType) (*jen.Statement, error)
 	GetSchema(*Converter, string, *FieldInformation) (*jen.Statement, *jen.Statement, error)
 }
 
@@ -66,8 +73,22 @@ type SimpleAttributeConverter interface {
 	GetType() *jen.Statement
 }
 
+// AttributeKindConverter is implemented by the attribute converters that can
+// tell the kind of attribute they render, e.g. "String" for StringAttribute. It
+// is used to find the types and packages of the validators and plan modifiers.
+type AttributeKindConverter interface {
+	GetAttributeKind(*FieldInformation) string
+}
+
+// HelperConverter is implemented by the attribute converters whose generated
+// code calls helper functions, GetHelpers returns the code added once to the
+// decoders and the encoders files of the models when the converter is used
+type HelperConverter interface {
+	GetHelpers(*Converter) (*jen.Statement, *jen.Statement, error)
+}
+
 type NoConverterFoundError struct {
-	typ reflect.Type
+	typ GoType
 }
 
 func (e *NoConverterFoundError) Error() string {
@@ -79,34 +100,50 @@ func (e *NoConverterFoundError) Error() string {
 
 type Converter struct {
 	attributeConverters []AttributeConverter
-	names               *map[reflect.Type]string
-	userGivenType       map[reflect.Type]struct{}
+	names               *map[GoType]string
 	getFieldInformation FieldInformationGetter
-	schemaImportPath    string
+	schemaType          SchemaType
+	docs                *docComments
+
+	// attributePaths and references are used to check the attributes
+	// referenced by the cross-attribute validators once the schema has been
+	// rendered
+	attributePaths map[string]bool
+	references     []attributeReference
+
+	// computed makes all the attributes computed, it is set while rendering
+	// the elements of the list data sources
+	computed bool
+
+	// docsCache keeps the doc comments loaded when the converter is shared
+	// by several generators
+	docsCache *docComments
+
+	// used records the attribute converters that rendered a decoder or an
+	// encoder so that their helpers can be added to the models
+	used map[AttributeConverter]bool
 }
 
-func NewConverter(attributeConverters []AttributeConverter, names *map[reflect.Type]string, getFieldInformation FieldInformationGetter, schemaImportPath string) *Converter {
+func NewConverter(attributeConverters []AttributeConverter, names *map[GoType]string, getFieldInformation FieldInformationGetter, schemaType SchemaType) *Converter {
 	c := &Converter{
 		attributeConverters: attributeConverters,
 		names:               names,
-		userGivenType:       map[reflect.Type]struct{}{},
 		getFieldInformation: getFieldInformation,
-		schemaImportPath:    schemaImportPath,
-	}
-
-	// We keep track of the types given by the user so that we can return the
-	// appropriate case for the encoding function
-	for k := range *names {
-		c.userGivenType[k] = struct{}{}
+		schemaType:          schemaType,
+		attributePaths:      map[string]bool{},
+		used:                map[AttributeConverter]bool{},
 	}
 
 	return c
 }
 
-func (c *Converter) Get(typ reflect.Type) (AttributeConverter, error) {
+func (c *Converter) Get(typ GoType) (AttributeConverter, error) {
 	if c == nil {
 		return nil, &NoConverterFoundError{}
 	}
+	if invalid, ok := typ.(*invalidType); ok {
+		return nil, invalid.err
+	}
 	for _, converter := range c.attributeConverters {
 		ok, err := converter.Check(typ)
 		if err != nil {
@@ -120,7 +157,7 @@ func (c *Converter) Get(typ reflect.Type) (AttributeConverter, error) {
 	return nil, &NoConverterFoundError{typ}
 }
 
-func (c *Converter) GetFrameworkType(typ reflect.Type) (*jen.Statement, error) {
+func (c *Converter) GetFrameworkType(typ GoType) (*jen.Statement, error) {
 	converter, err := c.Get(typ)
 	if err != nil {
 		return nil, err
@@ -129,25 +166,58 @@ func (c *Converter) GetFrameworkType(typ reflect.Type) (*jen.Statement, error) {
 	return validate("GetFrameworkType()", converter, typ, stmt, err)
 }
 
-func (c *Converter) Decode(field *FieldInformation, path, src, dest *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *Converter) Decode(field *FieldInformation, path, src, dest *jen.Statement, typ GoType) (*jen.Statement, error) {
 	converter, err := c.Get(typ)
 	if err != nil {
 		return nil, err
 	}
+	c.markUsed(converter)
 	stmt, err := converter.Decode(c, field, path, src, dest, typ)
 	return validate("Decode()", converter, typ, stmt, err)
 }
 
-func (c *Converter) Encode(field *FieldInformation, src, dest *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *Converter) Encode(field *FieldInformation, path, src, dest *jen.Statement, typ GoType) (*jen.Statement, error) {
 	converter, err := c.Get(typ)
 	if err != nil {
 		return nil, err
 	}
-	stmt, err := converter.Encode(c, field, src, dest, typ)
+	c.markUsed(converter)
+	stmt, err := converter.Encode(c, field, path, src, dest, typ)
 	return validate("Encode()", converter, typ, stmt, err)
 }
 
-func (c *Converter) GetNamesForType(typ reflect.Type) (string, string, string, string, error) {
+// markUsed records that converter rendered some code, only the converters
+// with helpers are recorded
+func (c *Converter) markUsed(converter AttributeConverter) {
+	if _, ok := converter.(HelperConverter); ok {
+		c.used[converter] = true
+	}
+}
+
+// getHelpers returns the helpers of the attribute converters used to render
+// the decoders and the encoders
+func (c *Converter) getHelpers() (*jen.Statement, *jen.Statement, error) {
+	decoders, encoders := jen.Empty(), jen.Empty()
+	for _, converter := range c.attributeConverters {
+		if !c.used[converter] {
+			continue
+		}
+		helperConverter := converter.(HelperConverter)
+		d, e, err := helperConverter.GetHelpers(c)
+		if err != nil {
+			return nil, nil, err
+		}
+		if d != nil {
+			decoders.Line().Add(d)
+		}
+		if e != nil {
+			encoders.Line().Add(e)
+		}
+	}
+	return decoders, encoders, nil
+}
+
+func (c *Converter) GetNamesForType(typ GoType) (string, string, string, string, error) {
 	name := (*c.names)[typ]
 	if name == "" {
 		name = typ.Name()
@@ -170,27 +240,87 @@ func (c *Converter) GetNamesForType(typ reflect.Type) (string, string, string, s
 	}
 	(*c.names)[typ] = name
 
-	encodingFuncName := "encode" + name
-	if _, found := c.userGivenType[typ]; found {
-		encodingFuncName = strings.ToUpper(encodingFuncName[:1]) + encodingFuncName[1:]
-	}
-
-	return name, strcase.LowerCamelCase(name), "decode" + name, encodingFuncName, nil
+	return name, strcase.LowerCamelCase(name), "decode" + name, "encode" + name, nil
 }
 
-func (c *Converter) GetFields(path string, typ reflect.Type) ([]*FieldInformation, error) {
-	fields, _, err := iterateFields(path, c.getFieldInformation, typ)
+func (c *Converter) GetFields(path string, typ GoType) ([]*FieldInformation, error) {
+	fields, _, err := iterateFields(c.tagSchemaType(), path, c.getFieldInformation, typ)
 	if err != nil {
 		return nil, err
 	}
+	if c.schemaType == "" {
+		return fields, nil
+	}
+
+	if c.schemaType == DataSourceFromResource || c.computed {
+		for _, field := range fields {
+			if err := dataSourceField(field, !c.computed); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := c.applyDocComments(fields); err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		c.attributePaths[field.Path] = isNestedCollection(field.goType)
+	}
+	for _, field := range fields {
+		if err := c.resolveDefault(field); err != nil {
+			return nil, err
+		}
+		if err := c.resolveValidators(field); err != nil {
+			return nil, err
+		}
+		if err := c.resolvePlanModifiers(field); err != nil {
+			return nil, err
+		}
+	}
 	return fields, nil
 }
 
+// configure prepares the converter to render the schema type, or the models
+// when it is empty, using opts. The naming table is kept so that the converter
+// can be shared by several generators.
+func (c *Converter) configure(schemaType SchemaType, opts *GeneratorOptions) {
+	c.attributeConverters = opts.AttributeConverters
+	c.getFieldInformation = opts.GetFieldInformation
+	c.schemaType = schemaType
+	c.attributePaths = map[string]bool{}
+	c.references = nil
+	c.computed = false
+	c.used = map[AttributeConverter]bool{}
+
+	c.docs = nil
+	if opts.UseDocComments {
+		if c.docsCache == nil {
+			c.docsCache = newDocComments()
+		}
+		c.docs = c.docsCache
+	}
+}
+
+// tagSchemaType returns the schema type the tags are read for, they are written
+// for the resource when the data source schema is derived from it
+func (c *Converter) tagSchemaType() SchemaType {
+	if c.schemaType == DataSourceFromResource {
+		return ResourceSchema
+	}
+	return c.schemaType
+}
+
 func (c *Converter) SchemaImportPath() string {
-	return c.schemaImportPath
+	return c.schemaType.importPath()
+}
+
+// SchemaType returns the type of the schema being rendered, it is empty when
+// the models are rendered
+func (c *Converter) SchemaType() SchemaType {
+	return c.schemaType
 }
 
-func validate(name string, c AttributeConverter, typ reflect.Type, stmt *jen.Statement, err error) (*jen.Statement, error) {
+func validate(name string, c AttributeConverter, typ GoType, stmt *jen.Statement, err error) (*jen.Statement, error) {
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stoewer/go-strcase"
+)
+
+// dataSourceField converts the information of a field tagged for a resource
+// to the one used in the data source derived from it. All the attributes are
+// computed except the lookup keys when lookup is set, the blocks become nested
+// attributes since data sources cannot have computed blocks, and the defaults,
+// plan modifiers and validators are removed.
+func dataSourceField(info *FieldInformation, lookup bool) error {
+	if lookup && info.Lookup {
+		// Only the attributes of the root object are given by the user
+		if strings.Count(info.Path, ".") != 1 {
+			return fmt.Errorf("%s: the lookup modifier can only be used on the attributes of the root object", info.Path)
+		}
+		if !info.Required {
+			info.Optional = true
+			info.Computed = true
+		}
+	} else {
+		info.Optional = false
+		info.Required = false
+		info.Computed = true
+	}
+
+	info.Block = false
+	info.DefaultLiteral = ""
+	info.HasDefault = false
+	info.Default = nil
+	info.Constraints = nil
+	info.Validators = nil
+	info.PlanModifierNames = nil
+	info.PlanModifiers = nil
+	return nil
+}
+
+// getFilterFields returns the fields of typ using the filterable modifier,
+// they are the optional attributes of the filter block of the list data
+// sources
+func (c *Converter) getFilterFields(path string, typ GoType) ([]*FieldInformation, error) {
+	fields, _, err := iterateFields(c.tagSchemaType(), path, c.getFieldInformation, typ)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.applyDocComments(fields); err != nil {
+		return nil, err
+	}
+
+	filters := []*FieldInformation{}
+	for _, field := range fields {
+		if !field.Filterable {
+			continue
+		}
+
+		converter, err := c.Get(field.goType)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := converter.(SimpleAttributeConverter); !ok {
+			return nil, fmt.Errorf("%s: only the attributes with a simple type can be filterable", field.Path)
+		}
+
+		if err := dataSourceField(field, false); err != nil {
+			return nil, err
+		}
+		field.Optional = true
+		field.Computed = false
+		filters = append(filters, field)
+	}
+	return filters, nil
+}
+
+// listDataSourceNames returns the names of the models of a list data source
+// and of its filter
+func listDataSourceNames(name string) (string, string) {
+	model := strcase.UpperCamelCase(name)
+	return model, model + "Filter"
+}
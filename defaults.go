@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// DefaultConverter is implemented by the attribute converters that support the
+// default modifier, GetDefault receives the literal given in the tag and must
+// return the schema default for it
+type DefaultConverter interface {
+	GetDefault(*Converter, *FieldInformation, string) (*jen.Statement, error)
+}
+
+// ValueConverter is implemented by the attribute converters that can render an
+// attr.Value, it is used to build the defaults of lists, sets, maps and
+// objects. The values are decoded from JSON so GetValue can receive a bool, a
+// string, a json.Number, a []interface{}, a map[string]interface{} or nil.
+type ValueConverter interface {
+	GetAttrType(*Converter, *FieldInformation, GoType) (*jen.Statement, error)
+	GetValue(*Converter, *FieldInformation, GoType, interface{}) (*jen.Statement, error)
+}
+
+func (c *Converter) getValueConverter(info *FieldInformation, typ GoType) (ValueConverter, error) {
+	converter, err := c.Get(typ)
+	if err != nil {
+		return nil, err
+	}
+	valueConverter, ok := converter.(ValueConverter)
+	if !ok {
+		return nil, fmt.Errorf("%s: %T does not support default values", info.Path, converter)
+	}
+	return valueConverter, nil
+}
+
+func (c *Converter) GetAttrType(info *FieldInformation, typ GoType) (*jen.Statement, error) {
+	converter, err := c.getValueConverter(info, typ)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := converter.GetAttrType(c, info, typ)
+	return validate("GetAttrType()", converter.(AttributeConverter), typ, stmt, err)
+}
+
+func (c *Converter) GetValue(info *FieldInformation, typ GoType, value interface{}) (*jen.Statement, error) {
+	converter, err := c.getValueConverter(info, typ)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := converter.GetValue(c, info, typ, value)
+	return validate("GetValue()", converter.(AttributeConverter), typ, stmt, err)
+}
+
+// resolveDefault converts the default literal found in the tag to the
+// framework default using the converter of the field
+func (c *Converter) resolveDefault(info *FieldInformation) error {
+	if !info.HasDefault {
+		return nil
+	}
+	if c.schemaType != ResourceSchema {
+		// The same type is often used for the resource and its data source
+		// so we ignore the default values like the plan modifiers
+		return nil
+	}
+	if info.Block {
+		return fmt.Errorf("%s: blocks cannot have a default value", info.Path)
+	}
+
+	converter, err := c.Get(info.goType)
+	if err != nil {
+		return err
+	}
+	defaultConverter, ok := converter.(DefaultConverter)
+	if !ok {
+		return fmt.Errorf("%s: %T does not support default values", info.Path, converter)
+	}
+
+	stmt, err := defaultConverter.GetDefault(c, info, info.DefaultLiteral)
+	if err != nil {
+		return fmt.Errorf("%s: invalid default value %q: %w", info.Path, info.DefaultLiteral, err)
+	}
+
+	// The framework requires the attributes with a default value to be
+	// computed
+	info.Default = stmt
+	info.Computed = true
+	return nil
+}
+
+// decodeLiteral decodes the JSON literal used as the default value of lists,
+// sets, maps and objects
+func decodeLiteral(literal string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(literal))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return value, nil
+}
+
+// scalarLiteral returns the string representation of a scalar value, the
+// literal found in the tag is given as a string while the values found in a
+// JSON document can also be a number or a bool
+func scalarLiteral(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return fmt.Sprint(v), nil
+	}
+	return "", fmt.Errorf("expected a scalar value, got %T", value)
+}
@@ -0,0 +1,178 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// docComments loads the source of the Go packages to find the doc comments of
+// the types and of their fields, the packages are loaded lazily the first time
+// one of their types is looked up
+type docComments struct {
+	packages map[string]*packageDocs
+}
+
+type packageDocs struct {
+	types  map[string]string
+	fields map[string]map[string]string
+}
+
+func newDocComments() *docComments {
+	return &docComments{
+		packages: map[string]*packageDocs{},
+	}
+}
+
+// typeDoc returns the doc comment of typ
+func (d *docComments) typeDoc(typ GoType) (string, error) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	docs, err := d.load(typ.PkgPath())
+	if err != nil || docs == nil {
+		return "", err
+	}
+	return docs.types[typ.Name()], nil
+}
+
+// fieldDoc returns the doc comment of the field named name in typ
+func (d *docComments) fieldDoc(typ GoType, name string) (string, error) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	docs, err := d.load(typ.PkgPath())
+	if err != nil || docs == nil {
+		return "", err
+	}
+	return docs.fields[typ.Name()][name], nil
+}
+
+func (d *docComments) load(pkgPath string) (*packageDocs, error) {
+	if pkgPath == "" {
+		return nil, nil
+	}
+	if docs, found := d.packages[pkgPath]; found {
+		return docs, nil
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedSyntax}, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", pkgPath, err)
+	}
+	docs := &packageDocs{
+		types:  map[string]string{},
+		fields: map[string]map[string]string{},
+	}
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) != 0 {
+			return nil, fmt.Errorf("failed to load %s: %w", pkgPath, pkg.Errors[0])
+		}
+		for _, file := range pkg.Syntax {
+			docs.add(file)
+		}
+	}
+
+	d.packages[pkgPath] = docs
+	return docs, nil
+}
+
+func (p *packageDocs) add(file *ast.File) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			spec := spec.(*ast.TypeSpec)
+
+			// The doc comment is attached to the declaration unless the types
+			// are declared in a group
+			doc := spec.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			p.types[spec.Name.Name] = commentText(doc)
+
+			s, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			fields := map[string]string{}
+			for _, field := range s.Fields.List {
+				doc := field.Doc
+				if doc == nil {
+					doc = field.Comment
+				}
+				for _, name := range field.Names {
+					fields[name.Name] = commentText(doc)
+				}
+				// Embedded fields are named after their type
+				if len(field.Names) == 0 {
+					fields[embeddedName(field.Type)] = commentText(doc)
+				}
+			}
+			p.fields[spec.Name.Name] = fields
+		}
+	}
+}
+
+// applyDocComments uses the doc comments of the fields as their description
+// and deprecation message when they have not been given in the tag
+func (c *Converter) applyDocComments(fields []*FieldInformation) error {
+	if c.docs == nil {
+		return nil
+	}
+	for _, field := range fields {
+		doc, err := c.docs.fieldDoc(field.owner, field.goName)
+		if err != nil {
+			return err
+		}
+		description, deprecation := parseDocComment(doc)
+		if field.Description == "" {
+			field.Description = description
+		}
+		if field.DeprecationMessage == "" {
+			field.DeprecationMessage = deprecation
+		}
+	}
+	return nil
+}
+
+// parseDocComment splits the doc comment between the description and the
+// deprecation message found in the paragraph starting with "Deprecated:"
+func parseDocComment(doc string) (string, string) {
+	description := []string{}
+	deprecation := ""
+	for _, paragraph := range strings.Split(doc, "\n\n") {
+		if strings.HasPrefix(paragraph, "Deprecated:") {
+			deprecation = strings.Join(strings.Fields(strings.TrimPrefix(paragraph, "Deprecated:")), " ")
+			continue
+		}
+		description = append(description, paragraph)
+	}
+	return strings.TrimSpace(strings.Join(description, "\n\n")), deprecation
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+func commentText(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.Text())
+}
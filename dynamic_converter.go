@@ -0,0 +1,310 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+const (
+	attrImportPath  = "github.com/hashicorp/terraform-plugin-framework/attr"
+	diagImportPath  = "github.com/hashicorp/terraform-plugin-framework/diag"
+	pathImportPath  = "github.com/hashicorp/terraform-plugin-framework/path"
+	typesImportPath = "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DynamicConverter knows how to convert interface{} and any to types.Dynamic,
+// the values are converted by the decodeDynamicValue and encodeDynamic helpers
+// rendered with the models:
+//   - bool, the numbers and string are converted to types.Bool, types.Number
+//     and types.String
+//   - the slices and the arrays are converted to tuples and the maps with
+//     string keys to objects, like the lists and the objects of the Terraform
+//     configuration
+//
+// The values are decoded to bool, float64, string, []interface{} and
+// map[string]interface{} like encoding/json does, except for the integers
+// that fit in an int64 that are decoded to int64. The numbers that a float64
+// cannot represent without losing precision are reported as diagnostics.
+type DynamicConverter struct{}
+
+var _ AttributeConverter = &DynamicConverter{}
+var _ HelperConverter = &DynamicConverter{}
+
+func (c *DynamicConverter) Check(typ GoType) (bool, error) {
+	return isEmptyInterface(typ), nil
+}
+
+func (c *DynamicConverter) GetFrameworkType(_ *Converter, typ GoType) (*jen.Statement, error) {
+	return jen.Qual(typesImportPath, "Dynamic"), nil
+}
+
+func (c *DynamicConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	return jen.If().Op("!").Add(src.Clone()).Dot("IsNull").Call().Block(
+		jen.List(jen.Id("v"), jen.Id("d")).Op(":=").Id("decodeDynamicValue").Call(path, src.Clone().Dot("UnderlyingValue").Call()),
+		jen.Id("diags").Dot("Append").Call(jen.Id("d").Op("...")),
+		target.Op("=").Id("v"),
+	), nil
+}
+
+func (c *DynamicConverter) Encode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	return jen.If(src.Clone().Op("!=").Nil()).Block(
+		jen.List(jen.Id("v"), jen.Id("d")).Op(":=").Id("encodeDynamic").Call(path.Clone(), src.Clone()),
+		jen.Id("diags").Dot("Append").Call(jen.Id("d").Op("...")),
+		target.Op("=").Id("v"),
+	), nil
+}
+
+func (c *DynamicConverter) GetSchema(converters *Converter, path string, info *FieldInformation) (*jen.Statement, *jen.Statement, error) {
+	return basicSchema(converters.SchemaImportPath(), "DynamicAttribute", info, nil)
+}
+
+func (c *DynamicConverter) GetType() *jen.Statement {
+	return jen.Qual(typesImportPath, "DynamicType")
+}
+
+func (c *DynamicConverter) GetAttributeKind(_ *FieldInformation) string {
+	return "Dynamic"
+}
+
+func (c *DynamicConverter) GetValidators(_ *Converter, _ *FieldInformation, constraints []Constraint) ([]jen.Code, error) {
+	if len(constraints) != 0 {
+		return nil, unsupportedConstraint(constraints[0], "Dynamic")
+	}
+	return nil, nil
+}
+
+func (c *DynamicConverter) GetHelpers(_ *Converter) (*jen.Statement, *jen.Statement, error) {
+	return renderDecodeDynamicValue(), renderEncodeDynamic(), nil
+}
+
+// renderDecodeDynamicValue renders decodeDynamicValue, it converts the value
+// held by a types.Dynamic to a Go value
+func renderDecodeDynamicValue() *jen.Statement {
+	// decodeElements decodes the elements of a list, a set or a tuple
+	decodeElements := func(elemPath *jen.Statement) []jen.Code {
+		return []jen.Code{
+			jen.Id("res").Op(":=").Make(jen.Index().Interface(), jen.Len(jen.Id("v").Dot("Elements").Call())),
+			jen.For(jen.List(jen.Id("i"), jen.Id("e")).Op(":=").Range().Id("v").Dot("Elements").Call()).Block(
+				jen.List(jen.Id("elem"), jen.Id("d")).Op(":=").Id("decodeDynamicValue").Call(elemPath, jen.Id("e")),
+				jen.Id("diags").Dot("Append").Call(jen.Id("d").Op("...")),
+				jen.Id("res").Index(jen.Id("i")).Op("=").Id("elem"),
+			),
+			jen.Return(jen.Id("res"), jen.Id("diags")),
+		}
+	}
+	// decodeAttributes decodes the elements of a map or the attributes of
+	// an object
+	decodeAttributes := func(method string, elemPath *jen.Statement) []jen.Code {
+		return []jen.Code{
+			jen.Id("res").Op(":=").Make(jen.Map(jen.String()).Interface(), jen.Len(jen.Id("v").Dot(method).Call())),
+			jen.For(jen.List(jen.Id("k"), jen.Id("e")).Op(":=").Range().Id("v").Dot(method).Call()).Block(
+				jen.List(jen.Id("elem"), jen.Id("d")).Op(":=").Id("decodeDynamicValue").Call(elemPath, jen.Id("e")),
+				jen.Id("diags").Dot("Append").Call(jen.Id("d").Op("...")),
+				jen.Id("res").Index(jen.Id("k")).Op("=").Id("elem"),
+			),
+			jen.Return(jen.Id("res"), jen.Id("diags")),
+		}
+	}
+
+	code := jen.Comment("decodeDynamicValue converts the value of a types.Dynamic to bool, int64,").Line().
+		Comment("float64, string, []interface{} or map[string]interface{}").Line().
+		Func().Id("decodeDynamicValue").Params(
+		jen.Id("path").Qual(pathImportPath, "Path"),
+		jen.Id("value").Qual(attrImportPath, "Value"),
+	).Parens(jen.List(jen.Interface(), jen.Qual(diagImportPath, "Diagnostics"))).Block(
+		jen.Var().Id("diags").Qual(diagImportPath, "Diagnostics"),
+		jen.If(jen.Id("value").Op("==").Nil().Op("||").Id("value").Dot("IsNull").Call().Op("||").Id("value").Dot("IsUnknown").Call()).Block(
+			jen.Return(jen.Nil(), jen.Nil()),
+		),
+		jen.Line(),
+		jen.Switch(jen.Id("v").Op(":=").Id("value").Assert(jen.Id("type"))).Block(
+			jen.Case(jen.Qual(typesImportPath, "Dynamic")).Block(
+				jen.Return(jen.Id("decodeDynamicValue").Call(jen.Id("path"), jen.Id("v").Dot("UnderlyingValue").Call())),
+			),
+			jen.Case(jen.Qual(typesImportPath, "Bool")).Block(
+				jen.Return(jen.Id("v").Dot("ValueBool").Call(), jen.Nil()),
+			),
+			jen.Case(jen.Qual(typesImportPath, "String")).Block(
+				jen.Return(jen.Id("v").Dot("ValueString").Call(), jen.Nil()),
+			),
+			jen.Case(jen.Qual(typesImportPath, "Int64")).Block(
+				jen.Return(jen.Id("v").Dot("ValueInt64").Call(), jen.Nil()),
+			),
+			jen.Case(jen.Qual(typesImportPath, "Float64")).Block(
+				jen.Return(jen.Id("v").Dot("ValueFloat64").Call(), jen.Nil()),
+			),
+			jen.Case(jen.Qual(typesImportPath, "Number")).Block(
+				jen.Return(jen.Id("decodeDynamicNumber").Call(jen.Id("path"), jen.Id("v").Dot("ValueBigFloat").Call())),
+			),
+			jen.Case(jen.Qual(typesImportPath, "List")).Block(decodeElements(jen.Id("path").Dot("AtListIndex").Call(jen.Id("i")))...),
+			jen.Case(jen.Qual(typesImportPath, "Set")).Block(decodeElements(jen.Id("path").Dot("AtSetValue").Call(jen.Id("e")))...),
+			jen.Case(jen.Qual(typesImportPath, "Tuple")).Block(decodeElements(jen.Id("path").Dot("AtTupleIndex").Call(jen.Id("i")))...),
+			jen.Case(jen.Qual(typesImportPath, "Map")).Block(decodeAttributes("Elements", jen.Id("path").Dot("AtMapKey").Call(jen.Id("k")))...),
+			jen.Case(jen.Qual(typesImportPath, "Object")).Block(decodeAttributes("Attributes", jen.Id("path").Dot("AtName").Call(jen.Id("k")))...),
+		),
+		jen.Line(),
+		jen.Id("diags").Dot("AddAttributeError").Call(
+			jen.Id("path"),
+			jen.Lit("unsupported dynamic value"),
+			jen.Qual("fmt", "Sprintf").Call(jen.Lit("%T cannot be converted to a Go value"), jen.Id("value")),
+		),
+		jen.Return(jen.Nil(), jen.Id("diags")),
+	).Line().Line()
+
+	return code.Comment("decodeDynamicNumber converts f to an int64 when it is an integer that fits in").Line().
+		Comment("one and to a float64 otherwise, the numbers that a float64 cannot represent").Line().
+		Comment("without losing precision are reported").Line().
+		Func().Id("decodeDynamicNumber").Params(
+		jen.Id("path").Qual(pathImportPath, "Path"),
+		jen.Id("f").Op("*").Qual("math/big", "Float"),
+	).Parens(jen.List(jen.Interface(), jen.Qual(diagImportPath, "Diagnostics"))).Block(
+		jen.Var().Id("diags").Qual(diagImportPath, "Diagnostics"),
+		jen.If(jen.List(jen.Id("i"), jen.Id("accuracy")).Op(":=").Id("f").Dot("Int64").Call(), jen.Id("accuracy").Op("==").Qual("math/big", "Exact")).Block(
+			jen.Return(jen.Id("i"), jen.Nil()),
+		),
+		jen.Line(),
+		jen.Comment("The float64 must give back f when it is parsed with the precision of f,"),
+		jen.Comment("e.g. 0.1 can be decoded but not 0.10000000000000000001"),
+		jen.List(jen.Id("x"), jen.Id("_")).Op(":=").Id("f").Dot("Float64").Call(),
+		jen.List(jen.Id("g"), jen.Id("_"), jen.Id("err")).Op(":=").Qual("math/big", "ParseFloat").Call(
+			jen.Qual("strconv", "FormatFloat").Call(jen.Id("x"), jen.LitRune('g'), jen.Lit(-1), jen.Lit(64)),
+			jen.Lit(10),
+			jen.Id("f").Dot("Prec").Call(),
+			jen.Qual("math/big", "ToNearestEven"),
+		),
+		jen.If(jen.Id("err").Op("!=").Nil().Op("||").Id("g").Dot("Cmp").Call(jen.Id("f")).Op("!=").Lit(0)).Block(
+			jen.Id("diags").Dot("AddAttributeError").Call(
+				jen.Id("path"),
+				jen.Lit("invalid number"),
+				jen.Qual("fmt", "Sprintf").Call(jen.Lit("%s cannot be converted to a float64 without losing precision"), jen.Id("f").Dot("Text").Call(jen.LitRune('g'), jen.Lit(-1))),
+			),
+			jen.Return(jen.Nil(), jen.Id("diags")),
+		),
+		jen.Return(jen.Id("x"), jen.Nil()),
+	).Line()
+}
+
+// renderEncodeDynamic renders encodeDynamic and encodeDynamicValue, they
+// convert a Go value to a types.Dynamic
+func renderEncodeDynamic() *jen.Statement {
+	ctx := jen.Qual("context", "Background").Call()
+	returnError := func(msg *jen.Statement) *jen.Statement {
+		return jen.Block(
+			jen.Id("diags").Dot("AddAttributeError").Call(jen.Id("path"), jen.Lit("unsupported dynamic value"), msg),
+			jen.Return(jen.Nil(), jen.Id("diags")),
+		)
+	}
+	kinds := func(kinds ...string) []jen.Code {
+		codes := []jen.Code{}
+		for _, kind := range kinds {
+			codes = append(codes, jen.Qual("reflect", kind))
+		}
+		return codes
+	}
+
+	code := jen.Comment("encodeDynamic converts value to a types.Dynamic, see encodeDynamicValue").Line().
+		Func().Id("encodeDynamic").Params(
+		jen.Id("path").Qual(pathImportPath, "Path"),
+		jen.Id("value").Interface(),
+	).Parens(jen.List(jen.Qual(typesImportPath, "Dynamic"), jen.Qual(diagImportPath, "Diagnostics"))).Block(
+		jen.List(jen.Id("v"), jen.Id("diags")).Op(":=").Id("encodeDynamicValue").Call(jen.Id("path"), jen.Id("value")),
+		jen.If(jen.Id("diags").Dot("HasError").Call()).Block(
+			jen.Return(jen.Qual(typesImportPath, "DynamicNull").Call(), jen.Id("diags")),
+		),
+		jen.If(jen.List(jen.Id("d"), jen.Id("ok")).Op(":=").Id("v").Assert(jen.Qual(typesImportPath, "Dynamic")), jen.Id("ok")).Block(
+			jen.Return(jen.Id("d"), jen.Id("diags")),
+		),
+		jen.Return(jen.Qual(typesImportPath, "DynamicValue").Call(jen.Id("v")), jen.Id("diags")),
+	).Line().Line()
+
+	return code.Comment("encodeDynamicValue converts value to an attr.Value, the slices and the arrays").Line().
+		Comment("are converted to tuples and the maps to objects like in the Terraform").Line().
+		Comment("configuration, nil is converted to a null types.Dynamic").Line().
+		Func().Id("encodeDynamicValue").Params(
+		jen.Id("path").Qual(pathImportPath, "Path"),
+		jen.Id("value").Interface(),
+	).Parens(jen.List(jen.Qual(attrImportPath, "Value"), jen.Qual(diagImportPath, "Diagnostics"))).Block(
+		jen.Var().Id("diags").Qual(diagImportPath, "Diagnostics"),
+		jen.Id("v").Op(":=").Qual("reflect", "ValueOf").Call(jen.Id("value")),
+		jen.For(jen.Id("v").Dot("Kind").Call().Op("==").Qual("reflect", "Pointer").Op("||").Id("v").Dot("Kind").Call().Op("==").Qual("reflect", "Interface")).Block(
+			jen.If(jen.Id("v").Dot("IsNil").Call()).Block(
+				jen.Return(jen.Qual(typesImportPath, "DynamicNull").Call(), jen.Nil()),
+			),
+			jen.Id("v").Op("=").Id("v").Dot("Elem").Call(),
+		),
+		jen.Line(),
+		jen.Switch(jen.Id("v").Dot("Kind").Call()).Block(
+			jen.Case(jen.Qual("reflect", "Invalid")).Block(
+				jen.Return(jen.Qual(typesImportPath, "DynamicNull").Call(), jen.Nil()),
+			),
+			jen.Case(jen.Qual("reflect", "Bool")).Block(
+				jen.Return(jen.Qual(typesImportPath, "BoolValue").Call(jen.Id("v").Dot("Bool").Call()), jen.Nil()),
+			),
+			jen.Case(jen.Qual("reflect", "String")).Block(
+				jen.Return(jen.Qual(typesImportPath, "StringValue").Call(jen.Id("v").Dot("String").Call()), jen.Nil()),
+			),
+			jen.Case(kinds("Int", "Int8", "Int16", "Int32", "Int64")...).Block(
+				jen.Return(jen.Qual(typesImportPath, "NumberValue").Call(jen.New(jen.Qual("math/big", "Float")).Dot("SetInt64").Call(jen.Id("v").Dot("Int").Call())), jen.Nil()),
+			),
+			jen.Case(kinds("Uint", "Uint8", "Uint16", "Uint32", "Uint64")...).Block(
+				jen.Return(jen.Qual(typesImportPath, "NumberValue").Call(jen.New(jen.Qual("math/big", "Float")).Dot("SetUint64").Call(jen.Id("v").Dot("Uint").Call())), jen.Nil()),
+			),
+			jen.Case(kinds("Float32", "Float64")...).Block(
+				jen.Id("f").Op(":=").Id("v").Dot("Float").Call(),
+				jen.If(jen.Qual("math", "IsNaN").Call(jen.Id("f")).Op("||").Qual("math", "IsInf").Call(jen.Id("f"), jen.Lit(0))).Add(
+					returnError(jen.Qual("fmt", "Sprintf").Call(jen.Lit("%v cannot be converted to a number"), jen.Id("f"))),
+				),
+				jen.Return(jen.Qual(typesImportPath, "NumberValue").Call(jen.Qual("math/big", "NewFloat").Call(jen.Id("f"))), jen.Nil()),
+			),
+			jen.Case(kinds("Slice", "Array")...).Block(
+				jen.If(jen.Id("v").Dot("Kind").Call().Op("==").Qual("reflect", "Slice").Op("&&").Id("v").Dot("IsNil").Call()).Block(
+					jen.Return(jen.Qual(typesImportPath, "DynamicNull").Call(), jen.Nil()),
+				),
+				jen.Id("elementTypes").Op(":=").Make(jen.Index().Qual(attrImportPath, "Type"), jen.Id("v").Dot("Len").Call()),
+				jen.Id("elements").Op(":=").Make(jen.Index().Qual(attrImportPath, "Value"), jen.Id("v").Dot("Len").Call()),
+				jen.For(jen.Id("i").Op(":=").Lit(0), jen.Id("i").Op("<").Id("v").Dot("Len").Call(), jen.Id("i").Op("++")).Block(
+					jen.List(jen.Id("elem"), jen.Id("d")).Op(":=").Id("encodeDynamicValue").Call(jen.Id("path").Dot("AtTupleIndex").Call(jen.Id("i")), jen.Id("v").Dot("Index").Call(jen.Id("i")).Dot("Interface").Call()),
+					jen.Id("diags").Dot("Append").Call(jen.Id("d").Op("...")),
+					jen.If(jen.Id("d").Dot("HasError").Call()).Block(
+						jen.Return(jen.Nil(), jen.Id("diags")),
+					),
+					jen.Id("elementTypes").Index(jen.Id("i")).Op("=").Id("elem").Dot("Type").Call(ctx.Clone()),
+					jen.Id("elements").Index(jen.Id("i")).Op("=").Id("elem"),
+				),
+				jen.List(jen.Id("res"), jen.Id("d")).Op(":=").Qual(typesImportPath, "TupleValue").Call(jen.Id("elementTypes"), jen.Id("elements")),
+				jen.Id("diags").Dot("Append").Call(jen.Id("d").Op("...")),
+				jen.Return(jen.Id("res"), jen.Id("diags")),
+			),
+			jen.Case(jen.Qual("reflect", "Map")).Block(
+				jen.If(jen.Id("v").Dot("Type").Call().Dot("Key").Call().Dot("Kind").Call().Op("!=").Qual("reflect", "String")).Add(
+					returnError(jen.Qual("fmt", "Sprintf").Call(jen.Lit("%T cannot be converted to an object, its keys must be strings"), jen.Id("value"))),
+				),
+				jen.If(jen.Id("v").Dot("IsNil").Call()).Block(
+					jen.Return(jen.Qual(typesImportPath, "DynamicNull").Call(), jen.Nil()),
+				),
+				jen.Id("attributeTypes").Op(":=").Map(jen.String()).Qual(attrImportPath, "Type").Values(),
+				jen.Id("attributes").Op(":=").Map(jen.String()).Qual(attrImportPath, "Value").Values(),
+				jen.Id("iter").Op(":=").Id("v").Dot("MapRange").Call(),
+				jen.For(jen.Id("iter").Dot("Next").Call()).Block(
+					jen.Id("key").Op(":=").Id("iter").Dot("Key").Call().Dot("String").Call(),
+					jen.List(jen.Id("elem"), jen.Id("d")).Op(":=").Id("encodeDynamicValue").Call(jen.Id("path").Dot("AtName").Call(jen.Id("key")), jen.Id("iter").Dot("Value").Call().Dot("Interface").Call()),
+					jen.Id("diags").Dot("Append").Call(jen.Id("d").Op("...")),
+					jen.If(jen.Id("d").Dot("HasError").Call()).Block(
+						jen.Return(jen.Nil(), jen.Id("diags")),
+					),
+					jen.Id("attributeTypes").Index(jen.Id("key")).Op("=").Id("elem").Dot("Type").Call(ctx.Clone()),
+					jen.Id("attributes").Index(jen.Id("key")).Op("=").Id("elem"),
+				),
+				jen.List(jen.Id("res"), jen.Id("d")).Op(":=").Qual(typesImportPath, "ObjectValue").Call(jen.Id("attributeTypes"), jen.Id("attributes")),
+				jen.Id("diags").Dot("Append").Call(jen.Id("d").Op("...")),
+				jen.Return(jen.Id("res"), jen.Id("diags")),
+			),
+		),
+		jen.Line(),
+		jen.Id("diags").Dot("AddAttributeError").Call(
+			jen.Id("path"),
+			jen.Lit("unsupported dynamic value"),
+			jen.Qual("fmt", "Sprintf").Call(jen.Lit("%T cannot be converted to a dynamic value"), jen.Id("value")),
+		),
+		jen.Return(jen.Nil(), jen.Id("diags")),
+	).Line()
+}
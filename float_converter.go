@@ -3,6 +3,8 @@ package generator
 import (
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/dave/jennifer/jen"
 )
@@ -12,7 +14,7 @@ type FloatConverter struct{}
 
 var _ AttributeConverter = &FloatConverter{}
 
-func (c *FloatConverter) Check(typ reflect.Type) (bool, error) {
+func (c *FloatConverter) Check(typ GoType) (bool, error) {
 	if typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
 	}
@@ -24,11 +26,11 @@ func (c *FloatConverter) Check(typ reflect.Type) (bool, error) {
 	}
 }
 
-func (c *FloatConverter) GetFrameworkType(_ *Converter, typ reflect.Type) (*jen.Statement, error) {
+func (c *FloatConverter) GetFrameworkType(_ *Converter, typ GoType) (*jen.Statement, error) {
 	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "Float64"), nil
 }
 
-func (c *FloatConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *FloatConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	op := jen.Empty()
 	if typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
@@ -47,7 +49,7 @@ func (c *FloatConverter) Decode(converters *Converter, field *FieldInformation,
 	return decode(src, jen.Id("n").Op(":=").Add(code).Line().Add(target.Op("=").Add(op).Id("n")))
 }
 
-func (c *FloatConverter) Encode(converters *Converter, field *FieldInformation, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *FloatConverter) Encode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	ptr := false
 	if typ.Kind() == reflect.Pointer {
 		ptr = true
@@ -83,3 +85,81 @@ func (c *FloatConverter) GetSchema(converters *Converter, path string, info *Fie
 func (c *FloatConverter) GetType() *jen.Statement {
 	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "Float64Type")
 }
+
+func (c *FloatConverter) GetAttrType(_ *Converter, _ *FieldInformation, _ GoType) (*jen.Statement, error) {
+	return c.GetType(), nil
+}
+
+func (c *FloatConverter) GetValue(_ *Converter, _ *FieldInformation, typ GoType, value interface{}) (*jen.Statement, error) {
+	if value == nil {
+		return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "Float64Null").Call(), nil
+	}
+	f, err := parseFloat(typ, value)
+	if err != nil {
+		return nil, err
+	}
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "Float64Value").Call(jen.Lit(f)), nil
+}
+
+func (c *FloatConverter) GetDefault(_ *Converter, info *FieldInformation, literal string) (*jen.Statement, error) {
+	f, err := parseFloat(info.goType, literal)
+	if err != nil {
+		return nil, err
+	}
+	return jen.Qual(resourceSchemaImportPath+"float64default", "StaticFloat64").Call(jen.Lit(f)), nil
+}
+
+// parseFloat parses value as a float that must fit in typ
+func parseFloat(typ GoType, value interface{}) (float64, error) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	literal, err := scalarLiteral(value)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(literal, typ.Bits())
+}
+
+func (c *FloatConverter) GetAttributeKind(_ *FieldInformation) string {
+	return "Float64"
+}
+
+func (c *FloatConverter) GetValidators(_ *Converter, info *FieldInformation, constraints []Constraint) ([]jen.Code, error) {
+	importPath := validatorPackage("Float64")
+
+	codes := []jen.Code{}
+	var min, max *jen.Statement
+	var minValue, maxValue float64
+	for _, constraint := range constraints {
+		switch constraint.Name {
+		case "oneof":
+			values := []jen.Code{}
+			for _, v := range strings.Split(constraint.Value, "|") {
+				f, err := parseFloat(info.goType, v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid oneof value %q: %w", v, err)
+				}
+				values = append(values, jen.Lit(f))
+			}
+			codes = append(codes, jen.Qual(importPath, "OneOf").Call(values...))
+		case "min", "max":
+			f, err := parseFloat(info.goType, constraint.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value %q: %w", constraint.Name, constraint.Value, err)
+			}
+			if constraint.Name == "min" {
+				min, minValue = jen.Lit(f), f
+			} else {
+				max, maxValue = jen.Lit(f), f
+			}
+		default:
+			return nil, unsupportedConstraint(constraint, "Float64")
+		}
+	}
+	if min != nil && max != nil && minValue > maxValue {
+		return nil, fmt.Errorf("min %v is greater than max %v", minValue, maxValue)
+	}
+
+	return append(codes, boundValidators(importPath, "AtLeast", "AtMost", "Between", min, max)...), nil
+}
@@ -1,14 +1,149 @@
 package generator
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/dave/jennifer/jen"
 	"github.com/hashicorp/go-hclog"
 )
 
+// Generator renders Go code for the Terraform Plugin Framework
+type Generator interface {
+	Render() error
+}
+
+// sharedGenerator is implemented by the generators that can share their
+// converter, and so their naming table, with the other generators given to
+// RenderAll
+type sharedGenerator interface {
+	render(*Converter) error
+}
+
+// ModelGenerator renders the models, the decoders and the encoders of Objects
+// in Path, see GenerateModels
+type ModelGenerator struct {
+	Package string
+	Path    string
+	Objects map[string]interface{}
+	// Logger replaces the logger of Options when it is set
+	Logger  hclog.Logger
+	Options *GeneratorOptions
+}
+
+var _ Generator = &ModelGenerator{}
+
+func (g *ModelGenerator) Render() error {
+	return RenderAll(g)
+}
+
+func (g *ModelGenerator) render(c *Converter) error {
+	err := generateModels(c, g.Path, g.Package, g.Objects, generatorOptions(g.Options, g.Logger))
+	if err != nil && !isStale(err) {
+		return fmt.Errorf("failed to render the models in %s: %w", g.Path, err)
+	}
+	return err
+}
+
+// SchemaGenerator renders the schemas of Objects in Path, see GenerateSchema
+type SchemaGenerator struct {
+	Type    SchemaType
+	Package string
+	Path    string
+	Objects map[string]interface{}
+	// Logger replaces the logger of Options when it is set
+	Logger  hclog.Logger
+	Options *GeneratorOptions
+}
+
+var _ Generator = &SchemaGenerator{}
+
+func (g *SchemaGenerator) Render() error {
+	return RenderAll(g)
+}
+
+func (g *SchemaGenerator) render(c *Converter) error {
+	err := generateSchema(c, g.Type, g.Path, g.Package, g.Objects, generatorOptions(g.Options, g.Logger))
+	if err != nil && !isStale(err) {
+		return fmt.Errorf("failed to render the %s schemas in %s: %w", g.Type, g.Path, err)
+	}
+	return err
+}
+
+// RenderAll renders all the generators, ModelGenerator and SchemaGenerator
+// share the same converter so that the names of the types are consistent
+// across them. All the generators are rendered even when one of them fails
+// and the errors are joined. In check mode the stale files of all the
+// generators are reported in a single *StaleFilesError.
+func RenderAll(generators ...Generator) error {
+	names := map[GoType]string{}
+	converter := NewConverter(nil, &names, nil, "")
+
+	errs := []error{}
+	stale := &StaleFilesError{}
+	for _, generator := range generators {
+		var err error
+		if g, ok := generator.(sharedGenerator); ok {
+			err = g.render(converter)
+		} else {
+			err = generator.Render()
+		}
+		var staleErr *StaleFilesError
+		if errors.As(err, &staleErr) {
+			stale.Files = append(stale.Files, staleErr.Files...)
+		} else if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(stale.Files) != 0 {
+		errs = append(errs, stale)
+	}
+	return errors.Join(errs...)
+}
+
+func isStale(err error) bool {
+	var stale *StaleFilesError
+	return errors.As(err, &stale)
+}
+
+func generatorOptions(opts *GeneratorOptions, logger hclog.Logger) *GeneratorOptions {
+	opts = opts.validate()
+	if logger != nil {
+		opts.Logger = logger
+	}
+	return opts
+}
+
 type GeneratorOptions struct {
 	Logger              hclog.Logger
 	GetFieldInformation FieldInformationGetter
 	AttributeConverters []AttributeConverter
+
+	// UseDocComments loads the source of the Go packages to use the doc
+	// comments of the types and fields as the descriptions of the schemas
+	// and attributes
+	UseDocComments bool
+
+	// ListDataSources are the list data sources to render, the keys are their
+	// names and the values the type of their elements. They are rendered by
+	// GenerateModels and by GenerateSchema for the data source schemas.
+	ListDataSources map[string]interface{}
+
+	// Check renders the files in memory and compares them with the ones on
+	// disk instead of writing them, a *StaleFilesError is returned when they
+	// differ
+	Check bool
+
+	// Output is where the files are written, they are written on disk when
+	// it is nil
+	Output Output
+	// FileNames are the names of the rendered files, the empty ones are
+	// replaced by the DefaultFileNames
+	FileNames FileNames
+	// SplitFiles renders each object in its own files, e.g. coffee_schema.go
+	// and coffee_models.go, the nested types used by several objects are
+	// rendered in the common files
+	SplitFiles bool
 }
 
 func (o *GeneratorOptions) validate() *GeneratorOptions {
@@ -16,6 +151,8 @@ func (o *GeneratorOptions) validate() *GeneratorOptions {
 		Logger:              hclog.Default(),
 		GetFieldInformation: GetFieldInformationFromTerraformTag,
 		AttributeConverters: DefaultConverters,
+		Output:              OSOutput{},
+		FileNames:           DefaultFileNames,
 	}
 	if o == nil {
 		return res
@@ -30,6 +167,14 @@ func (o *GeneratorOptions) validate() *GeneratorOptions {
 	if o.GetFieldInformation != nil {
 		res.GetFieldInformation = o.GetFieldInformation
 	}
+	res.UseDocComments = o.UseDocComments
+	res.ListDataSources = o.ListDataSources
+	res.Check = o.Check
+	if o.Output != nil {
+		res.Output = o.Output
+	}
+	res.FileNames = o.FileNames.withDefaults()
+	res.SplitFiles = o.SplitFiles
 	return res
 }
 
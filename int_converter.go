@@ -2,19 +2,26 @@ package generator
 
 import (
 	"fmt"
+	"math"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/dave/jennifer/jen"
 )
 
 // IntConverter knows how to convert int, int8, int16, int32, int64, uint,
 // uint8, uint16, uint32, uint64, *int, *int8, *int16, *int32, *int64, *uint,
-// *uint8, *uint16, *uint32, *uint64
+// *uint8, *uint16, *uint32, *uint64, the fields using the number modifier are
+// left to the NumberConverter
 type IntConverter struct{}
 
 var _ AttributeConverter = &IntConverter{}
 
-func (c *IntConverter) Check(typ reflect.Type) (bool, error) {
+func (c *IntConverter) Check(typ GoType) (bool, error) {
+	if _, ok := typ.(*numberType); ok {
+		return false, nil
+	}
 	if typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
 	}
@@ -28,46 +35,70 @@ func (c *IntConverter) Check(typ reflect.Type) (bool, error) {
 	}
 }
 
-func (c *IntConverter) GetFrameworkType(_ *Converter, typ reflect.Type) (*jen.Statement, error) {
+func (c *IntConverter) GetFrameworkType(_ *Converter, typ GoType) (*jen.Statement, error) {
 	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "Int64"), nil
 }
 
-func (c *IntConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *IntConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	ptr := false
 	op := jen.Empty()
 	if typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
+		ptr = true
 		op = jen.Op("&")
 	}
 	code := src.Clone().Dot("ValueInt64").Call()
+	n := jen.Id("n")
+	if isNamedType(typ, "", "int64") {
+		return decode(src, n.Clone().Op(":=").Add(code).Line().Add(target.Op("=").Add(op).Add(n.Clone())))
+	}
+
+	// The named types, e.g. type Level int8, are converted to their own name
+	value := jen.Qual(typ.PkgPath(), typ.Name()).Call(n.Clone())
+	assign := target.Clone().Op("=").Add(value)
+	if ptr {
+		assign = jen.Id("v").Op(":=").Add(value).Line().Add(target.Clone().Op("=").Op("&").Id("v"))
+	}
+	if typ.Kind() == reflect.Int64 {
+		return decode(src, n.Clone().Op(":=").Add(code).Line().Add(assign))
+	}
+
+	// The values that do not fit in the Go type are reported instead of
+	// being truncated, the bounds of int and uint depend on the platform
+	var outOfRange *jen.Statement
 	switch typ.Kind() {
-	case reflect.Int:
-		code = jen.Int().Call(code)
-	case reflect.Int8:
-		code = jen.Int8().Call(code)
-	case reflect.Int16:
-		code = jen.Int16().Call(code)
-	case reflect.Int32:
-		code = jen.Int32().Call(code)
-	case reflect.Int64:
-		break
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		suffix := strings.TrimPrefix(typ.Kind().String(), "int")
+		outOfRange = n.Clone().Op("<").Qual("math", "MinInt"+suffix).Op("||").Add(n.Clone()).Op(">").Qual("math", "MaxInt"+suffix)
 	case reflect.Uint:
-		code = jen.Uint().Call(code)
-	case reflect.Uint8:
-		code = jen.Uint8().Call(code)
-	case reflect.Uint16:
-		code = jen.Uint16().Call(code)
-	case reflect.Uint32:
-		code = jen.Uint32().Call(code)
+		outOfRange = n.Clone().Op("<").Lit(0).Op("||").Uint64().Call(n.Clone()).Op(">").Qual("math", "MaxUint")
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		suffix := strings.TrimPrefix(typ.Kind().String(), "uint")
+		outOfRange = n.Clone().Op("<").Lit(0).Op("||").Add(n.Clone()).Op(">").Qual("math", "MaxUint"+suffix)
 	case reflect.Uint64:
-		code = jen.Uint64().Call(code)
+		outOfRange = n.Clone().Op("<").Lit(0)
 	default:
 		return nil, fmt.Errorf("unexpected type %s", typ.Name())
 	}
 
-	return decode(src, jen.Id("n").Op(":=").Add(code).Line().Add(target.Op("=").Add(op).Id("n")))
+	return decode(src, jen.If(n.Clone().Op(":=").Add(code), outOfRange).Block(
+		jen.Id("diags").Dot("Append").Call(jen.Qual("github.com/hashicorp/terraform-plugin-framework/diag", "NewAttributeErrorDiagnostic").Call(
+			path,
+			jen.Lit("invalid number"),
+			jen.Qual("fmt", "Sprintf").Call(jen.Lit("%d does not fit in "+article(typ.Kind().String())+" "+typ.Kind().String()), n.Clone()),
+		)),
+	).Else().Block(assign))
 }
 
-func (c *IntConverter) Encode(converters *Converter, field *FieldInformation, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+// article returns the indefinite article of the name of an integer type
+func article(name string) string {
+	if strings.HasPrefix(name, "i") {
+		return "an"
+	}
+	return "a"
+}
+
+func (c *IntConverter) Encode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	ptr := false
 	if typ.Kind() == reflect.Pointer {
 		ptr = true
@@ -75,7 +106,7 @@ func (c *IntConverter) Encode(converters *Converter, field *FieldInformation, sr
 	}
 
 	// Use framework functions if no convertion is needed
-	if typ.Kind() == reflect.Int64 {
+	if isNamedType(typ, "", "int64") {
 		method := "Int64Value"
 		if ptr {
 			method = "Int64PointerValue"
@@ -103,3 +134,132 @@ func (c *IntConverter) GetSchema(converters *Converter, path string, info *Field
 func (c *IntConverter) GetType() *jen.Statement {
 	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "Int64Type")
 }
+
+func (c *IntConverter) GetAttrType(_ *Converter, _ *FieldInformation, _ GoType) (*jen.Statement, error) {
+	return c.GetType(), nil
+}
+
+func (c *IntConverter) GetValue(_ *Converter, _ *FieldInformation, typ GoType, value interface{}) (*jen.Statement, error) {
+	if value == nil {
+		return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "Int64Null").Call(), nil
+	}
+	n, err := parseInt(typ, value)
+	if err != nil {
+		return nil, err
+	}
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "Int64Value").Call(jen.Lit(n)), nil
+}
+
+func (c *IntConverter) GetDefault(_ *Converter, info *FieldInformation, literal string) (*jen.Statement, error) {
+	n, err := parseInt(info.goType, literal)
+	if err != nil {
+		return nil, err
+	}
+	return jen.Qual(resourceSchemaImportPath+"int64default", "StaticInt64").Call(jen.Lit(n)), nil
+}
+
+// parseInt parses value as an integer that must fit in typ
+func parseInt(typ GoType, value interface{}) (int, error) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	literal, err := scalarLiteral(value)
+	if err != nil {
+		return 0, err
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(literal, 10, typ.Bits())
+		if err != nil {
+			return 0, err
+		}
+		return int(n), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(literal, 10, typ.Bits())
+		if err != nil {
+			return 0, err
+		}
+		if n > math.MaxInt64 {
+			return 0, fmt.Errorf("%d does not fit in an int64", n)
+		}
+		return int(n), nil
+	}
+	return 0, fmt.Errorf("unexpected type %s", typ.Name())
+}
+
+func (c *IntConverter) GetAttributeKind(_ *FieldInformation) string {
+	return "Int64"
+}
+
+func (c *IntConverter) GetValidators(converters *Converter, info *FieldInformation, constraints []Constraint) ([]jen.Code, error) {
+	importPath := validatorPackage("Int64")
+
+	codes := []jen.Code{}
+	var min, max *jen.Statement
+	var minValue, maxValue int
+	for _, constraint := range constraints {
+		switch constraint.Name {
+		case "oneof":
+			values := []jen.Code{}
+			for _, v := range strings.Split(constraint.Value, "|") {
+				n, err := parseInt(info.goType, v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid oneof value %q: %w", v, err)
+				}
+				values = append(values, jen.Lit(n))
+			}
+			codes = append(codes, jen.Qual(importPath, "OneOf").Call(values...))
+		case "min", "max":
+			n, err := parseInt(info.goType, constraint.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value %q: %w", constraint.Name, constraint.Value, err)
+			}
+			if constraint.Name == "min" {
+				min, minValue = jen.Lit(n), n
+			} else {
+				max, maxValue = jen.Lit(n), n
+			}
+		default:
+			return nil, unsupportedConstraint(constraint, "Int64")
+		}
+	}
+	if min != nil && max != nil && minValue > maxValue {
+		return nil, fmt.Errorf("min %d is greater than max %d", minValue, maxValue)
+	}
+
+	// The values that do not fit in the Go type are rejected at plan time,
+	// the values given to oneof already fit in it
+	if len(codes) == 0 && converters.configurable(info) {
+		typeMin, typeMax := intBounds(info.goType)
+		if min == nil {
+			min = typeMin
+		}
+		if max == nil {
+			max = typeMax
+		}
+	}
+
+	return append(codes, boundValidators(importPath, "AtLeast", "AtMost", "Between", min, max)...), nil
+}
+
+// intBounds returns the bounds of the integer type that are narrower than
+// those of an int64, they are nil otherwise
+func intBounds(typ GoType) (*jen.Statement, *jen.Statement) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	bits := typ.Bits()
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		if bits < 64 {
+			return jen.Lit(-1 << (bits - 1)), jen.Lit(1<<(bits-1) - 1)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if bits < 64 {
+			return jen.Lit(0), jen.Lit(1<<bits - 1)
+		}
+		return jen.Lit(0), nil
+	}
+	return nil, nil
+}
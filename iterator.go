@@ -1,11 +1,14 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+
+	"github.com/dave/jennifer/jen"
 )
 
-func iterateFields(path string, infoGetter FieldInformationGetter, typ reflect.Type) ([]*FieldInformation, []reflect.Type, error) {
+func iterateFields(schemaType SchemaType, path string, infoGetter FieldInformationGetter, typ GoType) ([]*FieldInformation, []GoType, error) {
 	// We accept to get a Struct or a pointer to a struct to simplify the code in
 	// the converters
 	if typ.Kind() == reflect.Pointer {
@@ -13,13 +16,15 @@ func iterateFields(path string, infoGetter FieldInformationGetter, typ reflect.T
 	}
 
 	type obj struct {
-		Type reflect.Type
+		Type GoType
 		Tag  *FieldInformation
 	}
 	queue := []obj{{Type: typ, Tag: nil}}
 
 	fields := []*FieldInformation{}
-	todo := []reflect.Type{}
+	todo := []GoType{}
+	names := map[string]*FieldInformation{}
+	goNames := map[string]*FieldInformation{}
 
 	for i := 0; i < len(queue); i++ {
 		o := queue[i]
@@ -31,24 +36,46 @@ func iterateFields(path string, infoGetter FieldInformationGetter, typ reflect.T
 
 		for i := 0; i < typ.NumField(); i++ {
 			field := typ.Field(i)
-			tag, err := infoGetter(path, typ, field)
+			tag, err := infoGetter(schemaType, path, typ, field)
+			if errors.Is(err, SkipField) {
+				continue
+			}
 			if err != nil {
 				return nil, nil, err
 			}
 			if tag == nil {
-				continue
+				// Embedded structs are promoted like they are by Go
+				if !field.Anonymous || derefType(field.Type).Kind() != reflect.Struct {
+					continue
+				}
+				tag = &FieldInformation{
+					Promoted: true,
+					goName:   field.Name,
+					goType:   field.Type,
+					accessor: jen.Dot(field.Name),
+				}
 			}
 
 			tag.Parent = o.Tag
+			tag.owner = typ
 
 			if tag.Promoted {
-				if tag.Parent != nil {
-					return nil, nil, fmt.Errorf("multiple attribute levels have been promoted")
+				fieldType := derefType(field.Type)
+				for p := tag.Parent; p != nil; p = p.Parent {
+					if derefType(p.goType) == fieldType {
+						return nil, nil, fmt.Errorf("%s.%s: %s is promoted recursively", typ.Name(), field.Name, fieldType)
+					}
 				}
-				if field.Type.Kind() == reflect.Pointer {
-					field.Type = field.Type.Elem()
+				if !field.IsExported() {
+					// The generated code lives in another package so the
+					// fields of an unexported embedded struct can only be
+					// reached through the Go promotion
+					if field.Type.Kind() == reflect.Pointer {
+						return nil, nil, fmt.Errorf("%s.%s: unexported embedded pointers cannot be promoted", typ.Name(), field.Name)
+					}
+					tag.accessor = jen.Null()
 				}
-				queue = append(queue, obj{Type: field.Type, Tag: tag})
+				queue = append(queue, obj{Type: fieldType, Tag: tag})
 				continue
 			}
 
@@ -62,9 +89,70 @@ func iterateFields(path string, infoGetter FieldInformationGetter, typ reflect.T
 
 			tag.Path = path + "." + tag.Name
 
+			if tag.Number {
+				if derefType(field.Type).Kind() != reflect.Uint64 {
+					return nil, nil, fmt.Errorf("%s: the number modifier can only be used on uint64, got %s", fieldName(tag), field.Type.String())
+				}
+				tag.goType = &numberType{field.Type}
+			}
+
+			// The fields of the promoted structs share the namespace of the
+			// attributes and of the model fields
+			if other, found := names[tag.Name]; found {
+				return nil, nil, fmt.Errorf("attribute %q of %s collides with the one of %s", tag.Name, fieldName(tag), fieldName(other))
+			}
+			if other, found := goNames[tag.goName]; found {
+				return nil, nil, fmt.Errorf("field %s collides with %s", fieldName(tag), fieldName(other))
+			}
+			names[tag.Name] = tag
+			goNames[tag.goName] = tag
+
 			fields = append(fields, tag)
 		}
 	}
 
 	return fields, todo, nil
 }
+
+// fieldName returns the name of the field prefixed by the promoted fields it
+// is reached through, e.g. Object.Metadata.Name
+func fieldName(field *FieldInformation) string {
+	name := field.goName
+	for p := field.Parent; p != nil; p = p.Parent {
+		name = p.goName + "." + name
+	}
+	owner := field.owner
+	for p := field.Parent; p != nil; p = p.Parent {
+		owner = p.owner
+	}
+	return owner.Name() + "." + name
+}
+
+// promotedAccessor returns the accessor of the field from the root struct by
+// chaining the accessors of the promoted structs it is reached through
+func promotedAccessor(field *FieldInformation) *jen.Statement {
+	accessor := field.accessor.Clone()
+	for p := field.Parent; p != nil; p = p.Parent {
+		accessor = p.accessor.Clone().Add(accessor)
+	}
+	return accessor
+}
+
+// promotedPointers returns the promoted fields that field is reached through
+// and that are pointers, starting from the root struct
+func promotedPointers(field *FieldInformation) []*FieldInformation {
+	pointers := []*FieldInformation{}
+	for p := field.Parent; p != nil; p = p.Parent {
+		if p.goType.Kind() == reflect.Pointer {
+			pointers = append([]*FieldInformation{p}, pointers...)
+		}
+	}
+	return pointers
+}
+
+func derefType(typ GoType) GoType {
+	if typ.Kind() == reflect.Pointer {
+		return typ.Elem()
+	}
+	return typ
+}
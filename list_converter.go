@@ -7,15 +7,17 @@ import (
 	"github.com/dave/jennifer/jen"
 )
 
+// ListConverter knows how to convert slices, they are rendered as lists or as
+// sets when the set modifier is used
 type ListConverter struct{}
 
 var _ AttributeConverter = &ListConverter{}
 
-func (c *ListConverter) Check(typ reflect.Type) (bool, error) {
+func (c *ListConverter) Check(typ GoType) (bool, error) {
 	return typ.Kind() == reflect.Slice, nil
 }
 
-func (c *ListConverter) GetFrameworkType(converters *Converter, typ reflect.Type) (*jen.Statement, error) {
+func (c *ListConverter) GetFrameworkType(converters *Converter, typ GoType) (*jen.Statement, error) {
 	subType, err := converters.GetFrameworkType(typ.Elem())
 	if err != nil {
 		return nil, err
@@ -23,8 +25,22 @@ func (c *ListConverter) GetFrameworkType(converters *Converter, typ reflect.Type
 	return jen.Index().Add(subType), nil
 }
 
-func (c *ListConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
-	code, err := converters.Decode(field, path.Dot("AtListIndex").Call(jen.Id("i")), jen.Id("data"), target.Clone().Index(jen.Id("i")), typ.Elem())
+func (c *ListConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	elemPath := path.Clone().Dot("AtListIndex").Call(jen.Id("i"))
+	if field.Set {
+		// The elements of a set are not indexed, we use their value when
+		// possible and fallback to the path of the set for nested objects
+		converter, err := converters.Get(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		elemPath = path.Clone()
+		if _, ok := converter.(SimpleAttributeConverter); ok {
+			elemPath = elemPath.Dot("AtSetValue").Call(jen.Id("data"))
+		}
+	}
+
+	code, err := converters.Decode(field, elemPath, jen.Id("data"), target.Clone().Index(jen.Id("i")), typ.Elem())
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +62,7 @@ func (c *ListConverter) Decode(converters *Converter, field *FieldInformation, p
 	), nil
 }
 
-func (c *ListConverter) Encode(converters *Converter, field *FieldInformation, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *ListConverter) Encode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	frameworkType, err := converters.GetFrameworkType(typ)
 	if err != nil {
 		return nil, err
@@ -54,7 +70,14 @@ func (c *ListConverter) Encode(converters *Converter, field *FieldInformation, s
 
 	typ = typ.Elem()
 
-	code, err := converters.Encode(field, jen.Id("attr"), target.Clone().Index(jen.Id("i")), typ)
+	// The values of the elements of a set are not known before they are
+	// encoded so we use the path of the set
+	elemPath := path.Clone().Dot("AtListIndex").Call(jen.Id("i"))
+	if field.Set {
+		elemPath = path.Clone()
+	}
+
+	code, err := converters.Encode(field, elemPath, jen.Id("attr"), target.Clone().Index(jen.Id("i")), typ)
 	if err != nil {
 		return nil, err
 	}
@@ -73,8 +96,13 @@ func (c *ListConverter) GetSchema(converters *Converter, path string, info *Fiel
 	if err != nil {
 		return nil, nil, err
 	}
+	kind := "List"
+	if info.Set {
+		kind = "Set"
+	}
+
 	if simpleConverter, ok := converter.(SimpleAttributeConverter); ok {
-		return jen.Qual(converters.SchemaImportPath(), "ListAttribute").ValuesFunc(func(g *jen.Group) {
+		return jen.Qual(converters.SchemaImportPath(), kind+"Attribute").ValuesFunc(func(g *jen.Group) {
 			g.Line().Id("ElementType").Op(":").Add(simpleConverter.GetType())
 			if info.Optional && !info.Block {
 				g.Line().Id("Optional").Op(":").True()
@@ -91,12 +119,18 @@ func (c *ListConverter) GetSchema(converters *Converter, path string, info *Fiel
 			if info.Description != "" {
 				g.Line().Id("MarkdownDescription").Op(":").Lit(info.Description)
 			}
+			if info.DeprecationMessage != "" {
+				g.Line().Id("DeprecationMessage").Op(":").Lit(info.DeprecationMessage)
+			}
 			if info.Default != nil {
 				g.Line().Id("Default").Op(":").Add(info.Default)
 			}
 			if info.Validators != nil {
 				g.Line().Id("Validators").Op(":").Add(info.Validators)
 			}
+			if info.PlanModifiers != nil {
+				g.Line().Id("PlanModifiers").Op(":").Add(info.PlanModifiers)
+			}
 			g.Line()
 		}), nil, nil
 	}
@@ -156,6 +190,18 @@ func (c *ListConverter) GetSchema(converters *Converter, path string, info *Fiel
 		if info.Description != "" {
 			g.Line().Id("MarkdownDescription").Op(":").Lit(info.Description)
 		}
+		if info.DeprecationMessage != "" {
+			g.Line().Id("DeprecationMessage").Op(":").Lit(info.DeprecationMessage)
+		}
+		if info.Default != nil && !info.Block {
+			g.Line().Id("Default").Op(":").Add(info.Default)
+		}
+		if info.Validators != nil {
+			g.Line().Id("Validators").Op(":").Add(info.Validators)
+		}
+		if info.PlanModifiers != nil {
+			g.Line().Id("PlanModifiers").Op(":").Add(info.PlanModifiers)
+		}
 		g.Line().Id("NestedObject").Op(":").Add(innerType).ValuesFunc(func(g *jen.Group) {
 			for _, code := range codes {
 				g.Line().Add(code)
@@ -165,12 +211,89 @@ func (c *ListConverter) GetSchema(converters *Converter, path string, info *Fiel
 	})
 
 	if info.Block {
-		attrType.Qual(converters.SchemaImportPath(), "ListNestedBlock")
+		attrType.Qual(converters.SchemaImportPath(), kind+"NestedBlock")
 		innerType.Qual(converters.SchemaImportPath(), "NestedBlockObject")
 		return nil, result, nil
 	}
 
-	attrType.Qual(converters.SchemaImportPath(), "ListNestedAttribute")
+	attrType.Qual(converters.SchemaImportPath(), kind+"NestedAttribute")
 	innerType.Qual(converters.SchemaImportPath(), "NestedAttributeObject")
 	return result, nil, nil
 }
+
+func (c *ListConverter) GetAttrType(converters *Converter, info *FieldInformation, typ GoType) (*jen.Statement, error) {
+	elemType, err := converters.GetAttrType(info, typ.Elem())
+	if err != nil {
+		return nil, err
+	}
+	kind := "ListType"
+	if info.Set {
+		kind = "SetType"
+	}
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", kind).Values(
+		jen.Id("ElemType").Op(":").Add(elemType),
+	), nil
+}
+
+func (c *ListConverter) GetValue(converters *Converter, info *FieldInformation, typ GoType, value interface{}) (*jen.Statement, error) {
+	elemType, err := converters.GetAttrType(info, typ.Elem())
+	if err != nil {
+		return nil, err
+	}
+	kind := "List"
+	if info.Set {
+		kind = "Set"
+	}
+	if value == nil {
+		return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", kind+"Null").Call(elemType), nil
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON array, got %T", value)
+	}
+	values := []jen.Code{}
+	for _, item := range items {
+		v, err := converters.GetValue(info, typ.Elem(), item)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", kind+"ValueMust").Call(
+		elemType,
+		jen.Index().Qual("github.com/hashicorp/terraform-plugin-framework/attr", "Value").Values(values...),
+	), nil
+}
+
+func (c *ListConverter) GetDefault(converters *Converter, info *FieldInformation, literal string) (*jen.Statement, error) {
+	value, err := decodeLiteral(literal)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, fmt.Errorf("expected a JSON array, got null")
+	}
+	code, err := converters.GetValue(info, info.goType, value)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := "listdefault"
+	if info.Set {
+		pkg = "setdefault"
+	}
+	return jen.Qual(resourceSchemaImportPath+pkg, "StaticValue").Call(code), nil
+}
+
+func (c *ListConverter) GetAttributeKind(info *FieldInformation) string {
+	if info.Set {
+		return "Set"
+	}
+	return "List"
+}
+
+func (c *ListConverter) GetValidators(_ *Converter, info *FieldInformation, constraints []Constraint) ([]jen.Code, error) {
+	return sizeValidators(c.GetAttributeKind(info), constraints)
+}
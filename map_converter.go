@@ -1,20 +1,24 @@
 package generator
 
 import (
+	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/dave/jennifer/jen"
 )
 
+// MapConverter knows how to convert maps with string keys, maps of objects are
+// rendered as nested attributes
 type MapConverter struct{}
 
 var _ AttributeConverter = &MapConverter{}
 
-func (c *MapConverter) Check(typ reflect.Type) (bool, error) {
+func (c *MapConverter) Check(typ GoType) (bool, error) {
 	return typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String, nil
 }
 
-func (c *MapConverter) GetFrameworkType(converters *Converter, typ reflect.Type) (*jen.Statement, error) {
+func (c *MapConverter) GetFrameworkType(converters *Converter, typ GoType) (*jen.Statement, error) {
 	subType, err := converters.GetFrameworkType(typ.Elem())
 	if err != nil {
 		return nil, err
@@ -22,8 +26,8 @@ func (c *MapConverter) GetFrameworkType(converters *Converter, typ reflect.Type)
 	return jen.Map(jen.String()).Add(subType), nil
 }
 
-func (c *MapConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
-	code, err := converters.Decode(field, path.Dot("AtMapKey").Call(jen.Id("key")), jen.Id("data"), target.Clone().Index(jen.Id("key")), typ.Elem())
+func (c *MapConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	code, err := converters.Decode(field, path.Clone().Dot("AtMapKey").Call(jen.Id("key")), jen.Id("data"), target.Clone().Index(jen.Id("key")), typ.Elem())
 	if err != nil {
 		return nil, err
 	}
@@ -48,14 +52,14 @@ func (c *MapConverter) Decode(converters *Converter, field *FieldInformation, pa
 	), nil
 }
 
-func (c *MapConverter) Encode(converters *Converter, field *FieldInformation, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *MapConverter) Encode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	frameworkType, err := converters.GetFrameworkType(typ)
 	if err != nil {
 		return nil, err
 	}
 	typ = typ.Elem()
 
-	code, err := converters.Encode(field, jen.Id("v"), target.Clone().Index(jen.Id("k")), typ)
+	code, err := converters.Encode(field, path.Clone().Dot("AtMapKey").Call(jen.Id("k")), jen.Id("v"), target.Clone().Index(jen.Id("k")), typ)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +87,11 @@ func (c *MapConverter) GetSchema(converters *Converter, path string, info *Field
 	if simpleConverter, ok := converter.(SimpleAttributeConverter); ok {
 		inner := simpleConverter.GetType()
 		if slice {
-			inner = jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "ListType").Values(
+			kind := "ListType"
+			if info.Set {
+				kind = "SetType"
+			}
+			inner = jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", kind).Values(
 				jen.Line().Id("ElemType").Op(":").Add(inner),
 				jen.Line(),
 			)
@@ -106,37 +114,55 @@ func (c *MapConverter) GetSchema(converters *Converter, path string, info *Field
 			if info.Description != "" {
 				g.Line().Id("MarkdownDescription").Op(":").Lit(info.Description)
 			}
+			if info.DeprecationMessage != "" {
+				g.Line().Id("DeprecationMessage").Op(":").Lit(info.DeprecationMessage)
+			}
 			if info.Default != nil {
 				g.Line().Id("Default").Op(":").Add(info.Default)
 			}
 			if info.Validators != nil {
 				g.Line().Id("Validators").Op(":").Add(info.Validators)
 			}
+			if info.PlanModifiers != nil {
+				g.Line().Id("PlanModifiers").Op(":").Add(info.PlanModifiers)
+			}
 			g.Line()
 		}), nil, nil
 	}
 
+	if slice {
+		return nil, nil, fmt.Errorf("%#v: maps of lists of objects are not supported", path)
+	}
+	if info.Block {
+		return nil, nil, fmt.Errorf("%#v: maps cannot be rendered as blocks", path)
+	}
+
 	fields, err := converters.GetFields(path, typ)
 	if err != nil {
 		return nil, nil, err
 	}
-	codes := []jen.Code{}
+
+	attrs := []jen.Code{}
 	for _, field := range fields {
 		converter, err := converters.Get(field.goType)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		attr, _, err := converter.GetSchema(converters, field.Path, field)
+		attr, b, err := converter.GetSchema(converters, field.Path, field)
 		if err != nil {
 			return nil, nil, err
 		}
+		if b != nil {
+			return nil, nil, fmt.Errorf("%#v: got blocks but this is an attribute", path)
+		}
 		if attr != nil {
-			codes = append(codes, jen.Lit(field.Name).Op(":").Add(attr))
+			attrs = append(attrs, jen.Line().Lit(field.Name).Op(":").Add(attr))
 		}
 	}
+	attrs = append(attrs, jen.Line())
 
-	return jen.Op("&").Qual(converters.SchemaImportPath(), "ListNestedAttribute").ValuesFunc(func(g *jen.Group) {
+	return jen.Op("&").Qual(converters.SchemaImportPath(), "MapNestedAttribute").ValuesFunc(func(g *jen.Group) {
 		if info.Optional {
 			g.Line().Id("Optional").Op(":").True()
 		}
@@ -152,15 +178,89 @@ func (c *MapConverter) GetSchema(converters *Converter, path string, info *Field
 		if info.Description != "" {
 			g.Line().Id("MarkdownDescription").Op(":").Lit(info.Description)
 		}
+		if info.DeprecationMessage != "" {
+			g.Line().Id("DeprecationMessage").Op(":").Lit(info.DeprecationMessage)
+		}
+		if info.Default != nil {
+			g.Line().Id("Default").Op(":").Add(info.Default)
+		}
+		if info.Validators != nil {
+			g.Line().Id("Validators").Op(":").Add(info.Validators)
+		}
+		if info.PlanModifiers != nil {
+			g.Line().Id("PlanModifiers").Op(":").Add(info.PlanModifiers)
+		}
 		g.Line().Id("NestedObject").Op(":").Qual(converters.SchemaImportPath(), "NestedAttributeObject").Values(
-			jen.Line().Id("Attributes").Op(":").Map(jen.String()).Qual(converters.SchemaImportPath(), "Attribute").ValuesFunc(func(g *jen.Group) {
-				for _, code := range codes {
-					g.Line().Add(code)
-				}
-				g.Line()
-			}),
+			jen.Line().Id("Attributes").Op(":").Map(jen.String()).Qual(converters.SchemaImportPath(), "Attribute").Values(attrs...),
 			jen.Line(),
 		)
 		g.Line()
 	}), nil, nil
 }
+
+func (c *MapConverter) GetAttrType(converters *Converter, info *FieldInformation, typ GoType) (*jen.Statement, error) {
+	elemType, err := converters.GetAttrType(info, typ.Elem())
+	if err != nil {
+		return nil, err
+	}
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "MapType").Values(
+		jen.Id("ElemType").Op(":").Add(elemType),
+	), nil
+}
+
+func (c *MapConverter) GetValue(converters *Converter, info *FieldInformation, typ GoType, value interface{}) (*jen.Statement, error) {
+	elemType, err := converters.GetAttrType(info, typ.Elem())
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "MapNull").Call(elemType), nil
+	}
+
+	items, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON object, got %T", value)
+	}
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := []jen.Code{}
+	for _, k := range keys {
+		v, err := converters.GetValue(info, typ.Elem(), items[k])
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, jen.Lit(k).Op(":").Add(v))
+	}
+
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "MapValueMust").Call(
+		elemType,
+		jen.Map(jen.String()).Qual("github.com/hashicorp/terraform-plugin-framework/attr", "Value").Values(values...),
+	), nil
+}
+
+func (c *MapConverter) GetDefault(converters *Converter, info *FieldInformation, literal string) (*jen.Statement, error) {
+	value, err := decodeLiteral(literal)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, fmt.Errorf("expected a JSON object, got null")
+	}
+	code, err := converters.GetValue(info, info.goType, value)
+	if err != nil {
+		return nil, err
+	}
+	return jen.Qual(resourceSchemaImportPath+"mapdefault", "StaticValue").Call(code), nil
+}
+
+func (c *MapConverter) GetAttributeKind(_ *FieldInformation) string {
+	return "Map"
+}
+
+func (c *MapConverter) GetValidators(_ *Converter, _ *FieldInformation, constraints []Constraint) ([]jen.Code, error) {
+	return sizeValidators("Map", constraints)
+}
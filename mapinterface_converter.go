@@ -1,53 +1,161 @@
 package generator
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"github.com/dave/jennifer/jen"
 )
 
-// MapInterfaceConverter knows how to convert map[string]interface{}
+const jsontypesImportPath = "github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+
+// MapInterfaceConverter knows how to convert the values holding arbitrary
+// JSON documents to the jsontypes.Normalized custom type of
+// terraform-plugin-framework-jsontypes, so that reordering the keys or
+// changing the whitespaces does not produce a diff:
+//   - map[string]interface{}, map[string]any and the types defined from them
+//   - []interface{} and the types defined from it
+//   - json.RawMessage
 type MapInterfaceConverter struct{}
 
 var _ AttributeConverter = &MapInterfaceConverter{}
 
-func (c *MapInterfaceConverter) Check(typ reflect.Type) (bool, error) {
-	switch reflect.Zero(typ).Interface().(type) {
-	case map[string]interface{}:
-		return true, nil
+// isRawMessage returns whether typ is json.RawMessage, it is an alias of
+// jsontext.Value since encoding/json/v2
+func isRawMessage(typ GoType) bool {
+	return isNamedType(typ, "encoding/json", "RawMessage") || isNamedType(typ, "encoding/json/jsontext", "Value")
+}
+
+func (c *MapInterfaceConverter) Check(typ GoType) (bool, error) {
+	switch typ.Kind() {
+	case reflect.Map:
+		return typ.Key().String() == "string" && isEmptyInterface(typ.Elem()), nil
+	case reflect.Slice:
+		return isRawMessage(typ) || isEmptyInterface(typ.Elem()), nil
 	}
 	return false, nil
 }
 
-func (c *MapInterfaceConverter) GetFrameworkType(_ *Converter, typ reflect.Type) (*jen.Statement, error) {
-	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "String"), nil
+// jsonValueType returns the code of typ
+func jsonValueType(typ GoType) *jen.Statement {
+	switch {
+	case isRawMessage(typ):
+		return jen.Qual("encoding/json", "RawMessage")
+	case typ.Name() != "":
+		return jen.Qual(typ.PkgPath(), typ.Name())
+	case typ.Kind() == reflect.Map:
+		return jen.Map(jen.String()).Interface()
+	}
+	return jen.Index().Interface()
+}
+
+func (c *MapInterfaceConverter) GetFrameworkType(_ *Converter, typ GoType) (*jen.Statement, error) {
+	return jen.Qual(jsontypesImportPath, "Normalized"), nil
 }
 
-func (c *MapInterfaceConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *MapInterfaceConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	if isRawMessage(typ) {
+		return decode(src, target.Op("=").Add(jsonValueType(typ)).Call(src.Clone().Dot("ValueString").Call()))
+	}
+
 	return jen.If().Op("!").Add(src.Clone()).Dot("IsNull").Call().Block(
-		jen.Var().Id("m").Map(jen.String()).Interface(),
-		jen.If(jen.Id("err").Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Index().Byte().Call(src.Clone().Dot("ValueString").Call()), jen.Op("&").Id("m")), jen.Id("err").Op("!=").Nil()).Block(
-			jen.Id("diags").Dot("AddAttributeError").Call(path, jen.Lit("failed to unmarshal json"), jen.Id("err").Dot("Error").Call()),
-			jen.Return(),
+		jen.Var().Id("v").Add(jsonValueType(typ)),
+		jen.If(jen.Id("err").Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Index().Byte().Call(src.Clone().Dot("ValueString").Call()), jen.Op("&").Id("v")), jen.Id("err").Op("!=").Nil()).Block(
+			jen.Id("diags").Dot("AddAttributeError").Call(path.Clone(), jen.Lit("failed to unmarshal json"), jen.Id("err").Dot("Error").Call()),
+		).Else().Block(
+			target.Op("=").Id("v"),
 		),
-		target.Op("=").Id("m"),
 	), nil
 }
 
-func (c *MapInterfaceConverter) Encode(converters *Converter, field *FieldInformation, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *MapInterfaceConverter) Encode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	if isRawMessage(typ) {
+		return jen.If(src.Clone().Op("!=").Nil()).Block(
+			target.Op("=").Qual(jsontypesImportPath, "NewNormalizedValue").Call(jen.String().Call(src.Clone())),
+		), nil
+	}
+
 	return jen.If(src.Clone().Op("!=").Nil()).Block(
 		jen.List(jen.Id("data"), jen.Id("err")).Op(":=").Qual("encoding/json", "Marshal").Call(src.Clone()),
 		jen.If(jen.Id("err").Op("!=").Nil()).Block(
-			jen.Panic(jen.Id("err")),
+			jen.Id("diags").Dot("AddAttributeError").Call(
+				path.Clone(),
+				jen.Lit("failed to marshal json"),
+				jen.Id("err").Dot("Error").Call(),
+			),
+		).Else().Block(
+			target.Op("=").Qual(jsontypesImportPath, "NewNormalizedValue").Call(jen.String().Call(jen.Id("data"))),
 		),
-		target.Op("=").Qual("github.com/hashicorp/terraform-plugin-framework/types", "StringValue").Call(jen.String().Call(jen.Id("data"))),
 	), nil
 }
 
 func (c *MapInterfaceConverter) GetSchema(converters *Converter, path string, info *FieldInformation) (*jen.Statement, *jen.Statement, error) {
-	return basicSchema(converters.SchemaImportPath(), "StringAttribute", info, nil)
+	customType := jen.Id("CustomType").Op(":").Add(c.GetType())
+	return basicSchema(converters.SchemaImportPath(), "StringAttribute", info, []jen.Code{customType})
 }
 
 func (c *MapInterfaceConverter) GetType() *jen.Statement {
-	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "StringType")
+	return jen.Qual(jsontypesImportPath, "NormalizedType").Values()
+}
+
+func (c *MapInterfaceConverter) GetAttrType(_ *Converter, _ *FieldInformation, _ GoType) (*jen.Statement, error) {
+	return c.GetType(), nil
+}
+
+func (c *MapInterfaceConverter) GetValue(_ *Converter, _ *FieldInformation, typ GoType, value interface{}) (*jen.Statement, error) {
+	if value == nil {
+		return jen.Qual(jsontypesImportPath, "NewNormalizedNull").Call(), nil
+	}
+	data, err := marshalJSONValue(typ, value)
+	if err != nil {
+		return nil, err
+	}
+	return jen.Qual(jsontypesImportPath, "NewNormalizedValue").Call(jen.Lit(data)), nil
+}
+
+func (c *MapInterfaceConverter) GetDefault(_ *Converter, info *FieldInformation, literal string) (*jen.Statement, error) {
+	value, err := decodeLiteral(literal)
+	if err != nil {
+		return nil, err
+	}
+
+	// We use the same representation as the encoder to avoid spurious diffs
+	data, err := marshalJSONValue(info.goType, value)
+	if err != nil {
+		return nil, err
+	}
+	return jen.Qual(resourceSchemaImportPath+"stringdefault", "StaticString").Call(jen.Lit(data)), nil
+}
+
+// marshalJSONValue checks that value can be decoded to typ and returns its
+// JSON representation
+func marshalJSONValue(typ GoType, value interface{}) (string, error) {
+	switch {
+	case isRawMessage(typ):
+	case typ.Kind() == reflect.Map:
+		if _, ok := value.(map[string]interface{}); !ok {
+			return "", fmt.Errorf("expected a JSON object, got %T", value)
+		}
+	default:
+		if _, ok := value.([]interface{}); !ok {
+			return "", fmt.Errorf("expected a JSON array, got %T", value)
+		}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *MapInterfaceConverter) GetAttributeKind(_ *FieldInformation) string {
+	return "String"
+}
+
+func (c *MapInterfaceConverter) GetValidators(_ *Converter, _ *FieldInformation, constraints []Constraint) ([]jen.Code, error) {
+	if len(constraints) != 0 {
+		return nil, unsupportedConstraint(constraints[0], "JSON")
+	}
+	return nil, nil
 }
@@ -2,26 +2,36 @@ package generator
 
 import (
 	"fmt"
-	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
-	"time"
 
 	. "github.com/dave/jennifer/jen" //lint:ignore ST1001 accept dot import
+	"github.com/stoewer/go-strcase"
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 )
 
 func GenerateModels(path, pkg string, objects map[string]interface{}, opts *GeneratorOptions) error {
 	opts = opts.validate()
+	names := map[GoType]string{}
+	converter := NewConverter(opts.AttributeConverters, &names, opts.GetFieldInformation, "")
+	return generateModels(converter, path, pkg, objects, opts)
+}
+
+// generateModels renders the models using converter, its naming table can be
+// shared with other generators
+func generateModels(converter *Converter, path, pkg string, objects map[string]interface{}, opts *GeneratorOptions) error {
+	converter.configure("", opts)
+	opts.Logger.Debug("generating models", "path", path)
 
 	userGiven := []string{}
-	names := map[reflect.Type]string{}
-	types := map[string]reflect.Type{}
+	names := *converter.names
+	types := map[string]GoType{}
 	done := map[string]bool{}
 	for key, obj := range objects {
-		names[reflect.TypeOf(obj)] = key
-		types[key] = reflect.TypeOf(obj)
+		names[TypeOf(obj)] = key
+		types[key] = TypeOf(obj)
 
 		if _, found := done[key]; found {
 			return fmt.Errorf("%s has been given multiple time", key)
@@ -33,11 +43,26 @@ func GenerateModels(path, pkg string, objects map[string]interface{}, opts *Gene
 
 	sort.Strings(userGiven)
 
-	converter := NewConverter(opts.AttributeConverters, &names, opts.GetFieldInformation, "")
+	listNames := []string{}
+	for name, obj := range opts.ListDataSources {
+		if _, found := done[name]; found {
+			return fmt.Errorf("%s has been given multiple time", name)
+		}
+		typ := TypeOf(obj)
+		if typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		types[name] = typ
+		listNames = append(listNames, name)
+	}
+	sort.Strings(listNames)
 
-	queue := []reflect.Type{}
+	queue := []GoType{}
 	for _, name := range userGiven {
-		queue = append(queue, reflect.TypeOf(objects[name]))
+		queue = append(queue, TypeOf(objects[name]))
+	}
+	for _, name := range listNames {
+		queue = append(queue, types[name])
 	}
 
 	cases := []Code{}
@@ -53,6 +78,12 @@ func GenerateModels(path, pkg string, objects map[string]interface{}, opts *Gene
 			Return().Id(publicName).Call(Id("ctx"), Id("getter"), Id("o"))),
 		)
 	}
+	for _, name := range listNames {
+		model, _ := listDataSourceNames(name)
+		cases = append(cases, Case(Op("**").Add(listDataSourceType(types[name]))).Block(
+			Return().Id("Decode"+model).Call(Id("ctx"), Id("getter"), Id("o"))),
+		)
+	}
 
 	modelFile := newFile(pkg)
 	decodersFile := newFile(pkg)
@@ -65,6 +96,9 @@ func GenerateModels(path, pkg string, objects map[string]interface{}, opts *Gene
 			typ := types[name]
 			g.Op("**").Qual(typ.PkgPath(), typ.Name())
 		}
+		for _, name := range listNames {
+			g.Op("**").Add(listDataSourceType(types[name]))
+		}
 	})).Params(Id("ctx").Qual("context", "Context"), Id("getter").Id("Getter"), Id("obj").Id("Target")).Qual("github.com/hashicorp/terraform-plugin-framework/diag", "Diagnostics").Block(
 		Switch(Id("o").Op(":=").Any().Call(Id("obj")).Assert(Id("type"))).BlockFunc(func(gr *Group) {
 			for _, c := range cases {
@@ -90,9 +124,16 @@ func GenerateModels(path, pkg string, objects map[string]interface{}, opts *Gene
 		if err != nil {
 			return err
 		}
+		publicName := strings.ToUpper(encodeFunctionName[:1]) + encodeFunctionName[1:]
 
 		cases = append(cases, Case(Op("*").Qual(typ.PkgPath(), typ.Name())).Block(
-			List(Id("converted"), Id("diags")).Op("=").Id(encodeFunctionName).Call(Id("o"))),
+			List(Id("converted"), Id("diags")).Op("=").Id(publicName).Call(Id("o"))),
+		)
+	}
+	for _, name := range listNames {
+		model, _ := listDataSourceNames(name)
+		cases = append(cases, Case(Op("*").Add(listDataSourceType(types[name]))).Block(
+			List(Id("converted"), Id("diags")).Op("=").Id("Encode"+model).Call(Id("o"))),
 		)
 	}
 
@@ -101,6 +142,9 @@ func GenerateModels(path, pkg string, objects map[string]interface{}, opts *Gene
 			typ := types[name]
 			g.Op("*").Qual(typ.PkgPath(), typ.Name())
 		}
+		for _, name := range listNames {
+			g.Op("*").Add(listDataSourceType(types[name]))
+		}
 	})).Params(Id("ctx").Qual("context", "Context"), Id("setter").Id("Setter"), Id("obj").Id("Model")).Qual("github.com/hashicorp/terraform-plugin-framework/diag", "Diagnostics").Block(
 		Var().Id("diags").Qual("github.com/hashicorp/terraform-plugin-framework/diag", "Diagnostics"),
 		Var().Id("converted").Interface(),
@@ -117,14 +161,18 @@ func GenerateModels(path, pkg string, objects map[string]interface{}, opts *Gene
 		Return().Id("diags"),
 	).Line()
 
-	privateDecodeFunctions := Empty()
+	// The code of the types is added to the files once all the types are
+	// known since, in split mode, their file depends on the objects they are
+	// reached from
+	rendered := []renderedType{}
+	children := map[GoType][]GoType{}
 
 	for i := 0; i < len(queue); i++ {
 		typ := queue[i]
 		if typ.Kind() != reflect.Struct {
-			return fmt.Errorf("this should not happen")
+			return fmt.Errorf("expected Struct, got %s", typ.String())
 		}
-		if typ == reflect.TypeOf(time.Time{}) {
+		if isNamedType(typ, "time", "Time") || isBigNumber(typ) {
 			continue
 		}
 		// If we have a name for this type it means it has already been
@@ -139,60 +187,233 @@ func GenerateModels(path, pkg string, objects map[string]interface{}, opts *Gene
 		}
 
 		done[name] = true
+		r := renderedType{typ: typ}
 
 		if slices.Contains(userGiven, name) {
-			code, err := renderPublicDecodeFunction(converter, typ)
+			r.publicDecode, err = renderPublicDecodeFunction(converter, typ)
 			if err != nil {
 				return err
 			}
-			decodersFile.Add(*code...)
 		}
 
-		code, err := renderDecodeFunction(converter, opts, typ)
+		r.privateDecode, err = renderDecodeFunction(converter, opts, typ)
 		if err != nil {
 			return err
 		}
-		privateDecodeFunctions.Add(*code...)
 
-		code, err = renderEncodeFunction(converter, opts, typ)
+		r.encode, err = renderEncodeFunction(converter, opts, typ)
 		if err != nil {
 			return err
 		}
-		encodersFile.Add(*code...)
+		if slices.Contains(userGiven, name) {
+			publicEncode, err := renderPublicEncodeFunction(converter, typ)
+			if err != nil {
+				return err
+			}
+			r.encode = publicEncode.Add(r.encode)
+		}
 
 		code, todo, err := renderObject(converter, opts, typ)
 		queue = append(queue, todo...)
 		if err != nil {
 			return err
 		}
+		r.model = code
+		children[typ] = todo
+		rendered = append(rendered, r)
+	}
+
+	common := &modelFiles{
+		models:          modelFile,
+		decoders:        decodersFile,
+		encoders:        encodersFile,
+		privateDecoders: Empty(),
+	}
+	files := map[string]*modelFiles{}
+	filesFor := func(owner string) *modelFiles {
+		if !opts.SplitFiles || owner == "" {
+			return common
+		}
+		if _, found := files[owner]; !found {
+			files[owner] = &modelFiles{
+				models:          newFile(pkg),
+				decoders:        newFile(pkg),
+				encoders:        newFile(pkg),
+				privateDecoders: Empty(),
+			}
+		}
+		return files[owner]
+	}
 
-		modelFile.Add(*code...)
+	roots := map[string]GoType{}
+	given := map[GoType]struct{}{}
+	for _, name := range userGiven {
+		roots[name] = types[name]
+		given[types[name]] = struct{}{}
+	}
+	for _, name := range listNames {
+		roots[name] = types[name]
+	}
+	owners := objectOwners(roots, names, given, children)
+	hasSharedModels := false
+	for _, r := range rendered {
+		f := filesFor(owners[r.typ])
+		hasSharedModels = hasSharedModels || f == common
+		f.models.Add(*r.model...)
+		if r.publicDecode != nil {
+			f.decoders.Add(*r.publicDecode...)
+		}
+		f.privateDecoders.Add(*r.privateDecode...)
+		f.encoders.Add(*r.encode...)
 	}
 
-	if err := modelFile.Save(filepath.Join(path, "models.go")); err != nil {
-		return err
+	if len(listNames) != 0 {
+		modelFile.Comment("ListDataSource is the Go representation of the list data sources")
+		modelFile.Type().Id("ListDataSource").Types(Id("T").Any()).Struct(
+			Id("Items").Index().Id("T"),
+			Id("Filter").Op("*").Id("T"),
+		).Line()
+		hasSharedModels = true
 	}
+	for _, name := range listNames {
+		model, filter := listDataSourceNames(name)
+		for _, n := range names {
+			if n == model || n == filter {
+				return fmt.Errorf("%s: the models of the list data source conflict with %s", name, n)
+			}
+		}
 
-	decodersFile.Add(privateDecodeFunctions)
+		models, decoders, encoders, err := renderListDataSource(converter, opts, name, types[name])
+		if err != nil {
+			return err
+		}
+		f := filesFor(name)
+		f.models.Add(*models...)
+		f.decoders.Add(*decoders...)
+		f.encoders.Add(*encoders...)
+	}
 
-	if err := decodersFile.Save(filepath.Join(path, "decoders.go")); err != nil {
+	// The helpers of the converters are shared by all the objects
+	decodeHelpers, encodeHelpers, err := converter.getHelpers()
+	if err != nil {
 		return err
 	}
+	common.privateDecoders.Add(decodeHelpers)
+	common.encoders.Add(encodeHelpers)
+
+	// The common models file only holds the shared types in split mode and
+	// is not rendered when there are none
+	common.decoders.Add(common.privateDecoders)
+	if opts.SplitFiles && !hasSharedModels {
+		common.models = nil
+	}
 
-	return encodersFile.Save(filepath.Join(path, "encoders.go"))
+	generated := []generatedFile{
+		{opts.FileNames.Models, common.models},
+		{opts.FileNames.Decoders, common.decoders},
+		{opts.FileNames.Encoders, common.encoders},
+	}
+	fileNames := map[string]string{}
+	for _, name := range append(userGiven, listNames...) {
+		f, found := files[name]
+		if !found {
+			continue
+		}
+		if err := checkObjectFileName(fileNames, name); err != nil {
+			return err
+		}
+		f.decoders.Add(f.privateDecoders)
+		generated = append(generated,
+			generatedFile{objectFileName(name, opts.FileNames.Models), f.models},
+			generatedFile{objectFileName(name, opts.FileNames.Decoders), f.decoders},
+			generatedFile{objectFileName(name, opts.FileNames.Encoders), f.encoders},
+		)
+	}
+
+	return saveFiles(opts, path, "models", []string{opts.FileNames.Models, opts.FileNames.Decoders, opts.FileNames.Encoders}, generated...)
+}
+
+// renderedType is the code rendered for a type by generateModels
+type renderedType struct {
+	typ           GoType
+	model         *Statement
+	publicDecode  *Statement
+	privateDecode *Statement
+	encode        *Statement
+}
+
+// modelFiles are the files holding the models of an object, or the shared
+// ones
+type modelFiles struct {
+	models          *File
+	decoders        *File
+	encoders        *File
+	privateDecoders *Statement
+}
+
+// objectOwners returns the name of the object whose files hold each type,
+// the types reached from several objects are shared and have no owner. roots
+// are the types of the objects and of the list data sources, the user given
+// types always belong to their own object.
+func objectOwners(roots map[string]GoType, names map[GoType]string, userGiven map[GoType]struct{}, children map[GoType][]GoType) map[GoType]string {
+	rootNames := maps.Keys(roots)
+	sort.Strings(rootNames)
+
+	reachedFrom := map[GoType][]string{}
+	for _, root := range rootNames {
+		visited := map[GoType]bool{}
+		var visit func(GoType)
+		visit = func(typ GoType) {
+			if visited[typ] {
+				return
+			}
+			visited[typ] = true
+			reachedFrom[typ] = append(reachedFrom[typ], root)
+			for _, child := range children[typ] {
+				if _, found := userGiven[child]; !found {
+					visit(child)
+				}
+			}
+		}
+		// The list data sources of a user given type do not own its types
+		if _, found := userGiven[roots[root]]; !found || names[roots[root]] == root {
+			visit(roots[root])
+		}
+	}
+
+	owners := map[GoType]string{}
+	for typ := range userGiven {
+		owners[typ] = names[typ]
+	}
+	for typ, from := range reachedFrom {
+		if len(from) == 1 {
+			owners[typ] = from[0]
+		}
+	}
+	return owners
 }
 
-func renderObject(c *Converter, opts *GeneratorOptions, typ reflect.Type) (*Statement, []reflect.Type, error) {
-	fields, todo, err := iterateFields("", opts.GetFieldInformation, typ)
+func renderObject(c *Converter, opts *GeneratorOptions, typ GoType) (*Statement, []GoType, error) {
+	fields, todo, err := iterateFields("", "", opts.GetFieldInformation, typ)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name, _, _, _, err := c.GetNamesForType(typ)
 	if err != nil {
 		return nil, nil, err
 	}
+	code, err := renderModel(c, name, fields)
+	return code, todo, err
+}
 
+// renderModel renders the model named name with the framework types of fields
+func renderModel(c *Converter, name string, fields []*FieldInformation) (*Statement, error) {
 	var codes []Code
 	for _, field := range fields {
 		code, err := c.GetFrameworkType(field.goType)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		codes = append(
@@ -201,15 +422,11 @@ func renderObject(c *Converter, opts *GeneratorOptions, typ reflect.Type) (*Stat
 		)
 	}
 
-	name, _, _, _, err := c.GetNamesForType(typ)
-	if err != nil {
-		return nil, nil, err
-	}
-	return Type().Id(name).Struct(codes...).Line(), todo, nil
+	return Type().Id(name).Struct(codes...).Line(), nil
 }
 
-func renderDecodeFunction(c *Converter, opts *GeneratorOptions, typ reflect.Type) (*Statement, error) {
-	fields, _, err := iterateFields("", opts.GetFieldInformation, typ)
+func renderDecodeFunction(c *Converter, opts *GeneratorOptions, typ GoType) (*Statement, error) {
+	fields, _, err := iterateFields("", "", opts.GetFieldInformation, typ)
 	if err != nil {
 		return nil, err
 	}
@@ -218,18 +435,33 @@ func renderDecodeFunction(c *Converter, opts *GeneratorOptions, typ reflect.Type
 	if err != nil {
 		return nil, err
 	}
+	return renderDecodeFields(c, typ, fields, name, ident, decodeFunctionName)
+}
 
+// renderDecodeFields renders the function decoding fields from the model
+// named name to typ
+func renderDecodeFields(c *Converter, typ GoType, fields []*FieldInformation, name, ident, decodeFunctionName string) (*Statement, error) {
 	codes := []Code{}
+	allocated := map[*FieldInformation]bool{}
 	for _, field := range fields {
-		target := Id("target")
-		if field.Parent != nil {
-			target.Add(field.Parent.accessor.Clone())
+		// The promoted pointers must be allocated before their fields can be
+		// set
+		for _, p := range promotedPointers(field) {
+			if allocated[p] {
+				continue
+			}
+			allocated[p] = true
+			elem := p.goType.Elem()
+			codes = append(codes, If(Id("target").Add(promotedAccessor(p)).Op("==").Nil()).Block(
+				Id("target").Add(promotedAccessor(p)).Op("=").Op("&").Qual(elem.PkgPath(), elem.Name()).Block(),
+			), Line())
 		}
+
 		code, err := c.Decode(
 			field,
 			Id("path").Dot("AtName").Call(Lit(field.Name)),
 			Id("data").Dot(field.goName),
-			target.Add(field.accessor),
+			Id("target").Add(promotedAccessor(field)),
 			field.goType,
 		)
 		if err != nil {
@@ -262,7 +494,7 @@ func renderDecodeFunction(c *Converter, opts *GeneratorOptions, typ reflect.Type
 	}).Line().Line(), nil
 }
 
-func renderPublicDecodeFunction(c *Converter, typ reflect.Type) (*Statement, error) {
+func renderPublicDecodeFunction(c *Converter, typ GoType) (*Statement, error) {
 	_, name, decodeFunctionName, _, err := c.GetNamesForType(typ)
 	if err != nil {
 		return nil, err
@@ -289,8 +521,25 @@ func renderPublicDecodeFunction(c *Converter, typ reflect.Type) (*Statement, err
 	).Line(), nil
 }
 
-func renderEncodeFunction(c *Converter, opts *GeneratorOptions, typ reflect.Type) (*Statement, error) {
-	fields, _, err := iterateFields("", opts.GetFieldInformation, typ)
+func renderPublicEncodeFunction(c *Converter, typ GoType) (*Statement, error) {
+	name, ident, _, encodeFunctionName, err := c.GetNamesForType(typ)
+	if err != nil {
+		return nil, err
+	}
+	publicName := strings.ToUpper(encodeFunctionName[:1]) + encodeFunctionName[1:]
+
+	return Func().Id(publicName).Params(
+		Id(ident).Op("*").Qual(typ.PkgPath(), typ.Name()),
+	).Parens(List(Op("*").Id(name), Qual("github.com/hashicorp/terraform-plugin-framework/diag", "Diagnostics"))).Block(
+		Return().Id(encodeFunctionName).Call(
+			Qual("github.com/hashicorp/terraform-plugin-framework/path", "Empty()"),
+			Id(ident),
+		),
+	).Line().Line(), nil
+}
+
+func renderEncodeFunction(c *Converter, opts *GeneratorOptions, typ GoType) (*Statement, error) {
+	fields, _, err := iterateFields("", "", opts.GetFieldInformation, typ)
 	if err != nil {
 		return nil, err
 	}
@@ -299,16 +548,18 @@ func renderEncodeFunction(c *Converter, opts *GeneratorOptions, typ reflect.Type
 	if err != nil {
 		return nil, err
 	}
+	return renderEncodeFields(c, typ, fields, name, ident, encodeFunctionName)
+}
 
+// renderEncodeFields renders the function encoding fields from typ to the
+// model named name
+func renderEncodeFields(c *Converter, typ GoType, fields []*FieldInformation, name, ident, encodeFunctionName string) (*Statement, error) {
 	codes := []Code{}
 	for _, field := range fields {
-		target := Id(ident)
-		if field.Parent != nil {
-			target = target.Add(field.Parent.accessor.Clone())
-		}
 		code, err := c.Encode(
 			field,
-			target.Add(field.accessor),
+			Id("path").Dot("AtName").Call(Lit(field.Name)),
+			Id(ident).Add(promotedAccessor(field)),
 			Id("res").Dot(field.goName),
 			field.goType,
 		)
@@ -316,10 +567,20 @@ func renderEncodeFunction(c *Converter, opts *GeneratorOptions, typ reflect.Type
 			return nil, err
 		}
 
+		// The fields of the nil promoted pointers are left null
+		if pointers := promotedPointers(field); len(pointers) != 0 {
+			cond := Id(ident).Add(promotedAccessor(pointers[0])).Op("!=").Nil()
+			for _, p := range pointers[1:] {
+				cond = cond.Op("&&").Id(ident).Add(promotedAccessor(p)).Op("!=").Nil()
+			}
+			code = If(cond).Block(code)
+		}
+
 		codes = append(codes, code)
 	}
 
 	return Func().Id(encodeFunctionName).Params(
+		Id("path").Qual("github.com/hashicorp/terraform-plugin-framework/path", "Path"),
 		Id(ident).Op("*").Qual(typ.PkgPath(), typ.Name()),
 	).Parens(List(Op("*").Id(name), Qual("github.com/hashicorp/terraform-plugin-framework/diag", "Diagnostics"))).BlockFunc(func(g *Group) {
 		g.If(Id(ident).Op("==").Nil()).Block(
@@ -335,3 +596,147 @@ func renderEncodeFunction(c *Converter, opts *GeneratorOptions, typ reflect.Type
 		g.Return().List(Op("&").Id("res"), Id("diags"))
 	}).Line(), nil
 }
+
+func listDataSourceType(typ GoType) *Statement {
+	return Id("ListDataSource").Types(Qual(typ.PkgPath(), typ.Name()))
+}
+
+// renderListDataSource renders the models of the list data source named name,
+// its decoders and its encoders
+func renderListDataSource(c *Converter, opts *GeneratorOptions, name string, typ GoType) (*Statement, *Statement, *Statement, error) {
+	model, filter := listDataSourceNames(name)
+	items := &FieldInformation{
+		Name:     name,
+		goName:   "Items",
+		goType:   SliceOf(typ),
+		accessor: Dot("Items"),
+	}
+	itemsType, err := c.GetFrameworkType(items.goType)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	filters, err := c.getFilterFields(name+".filter", typ)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// The filter block is only rendered in the schema when some of the
+	// attributes are filterable, the model must match it
+	hasFilter := len(filters) != 0
+
+	models := Type().Id(model).StructFunc(func(g *Group) {
+		g.Id("Items").Add(itemsType).Tag(map[string]string{"tfsdk": name})
+		if hasFilter {
+			g.Id("Filter").Op("*").Id(filter).Tag(map[string]string{"tfsdk": "filter"})
+		}
+	}).Line().Line()
+	if hasFilter {
+		filterModel, err := renderModel(c, filter, filters)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		models.Add(filterModel)
+	}
+
+	decodeItems, err := c.Decode(items, Id("path").Dot("AtName").Call(Lit(name)), Id("data").Dot("Items"), Id("target").Dot("Items"), items.goType)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ident := strcase.LowerCamelCase(model)
+	decoders := Func().Id("Decode"+model).Params(
+		Id("ctx").Qual("context", "Context"),
+		Id("getter").Id("Getter"),
+		Id(ident).Op("**").Add(listDataSourceType(typ)),
+	).Qual("github.com/hashicorp/terraform-plugin-framework/diag", "Diagnostics").Block(
+		Var().Id("data").Op("*").Id(model),
+		Id("diags").Op(":=").Id("getter").Dot("Get").Params(Id("ctx"), Op("&").Id("data")),
+		If(Id("diags").Dot("HasError").Call()).Block(
+			Return(Id("diags")),
+		),
+		Line(),
+		Id("diags").Dot("Append").Call(Id("decode"+model).Call(
+			Qual("github.com/hashicorp/terraform-plugin-framework/path", "Empty()"),
+			Id("data"),
+			Id(ident),
+		).Op("...")),
+		Return(Id("diags")),
+	).Line().Line()
+
+	decoders.Func().Id("decode"+model).Params(
+		Id("path").Qual("github.com/hashicorp/terraform-plugin-framework/path", "Path"),
+		Id("data").Op("*").Id(model),
+		Id(ident).Op("**").Add(listDataSourceType(typ)),
+	).Parens(Id("diags").Qual("github.com/hashicorp/terraform-plugin-framework/diag", "Diagnostics")).BlockFunc(func(g *Group) {
+		g.If(Id("data").Op("==").Nil()).Block(
+			Return().Nil(),
+		).Line()
+		g.Id("target").Op(":=").Op("&").Add(listDataSourceType(typ)).Block()
+		g.If(Op("*").Id(ident).Op("==").Nil()).Block(
+			Op("*").Id(ident).Op("=").Id("target"),
+		).Else().Block(
+			Id("target").Op("=").Op("*").Id(ident),
+		).Line()
+		g.Add(decodeItems)
+		if hasFilter {
+			g.Line()
+			g.Id("diags").Dot("Append").Call(Id("decode"+filter).Call(
+				Id("path").Dot("AtName").Call(Lit("filter")),
+				Id("data").Dot("Filter"),
+				Op("&").Id("target").Dot("Filter"),
+			).Op("..."))
+		}
+		g.Return(Id("diags"))
+	}).Line().Line()
+	if hasFilter {
+		decodeFilter, err := renderDecodeFields(c, typ, filters, filter, "filter", "decode"+filter)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		decoders.Add(decodeFilter)
+	}
+
+	encodeItems, err := c.Encode(items, Id("path").Dot("AtName").Call(Lit(name)), Id(ident).Dot("Items"), Id("res").Dot("Items"), items.goType)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	encoders := Func().Id("Encode" + model).Params(
+		Id(ident).Op("*").Add(listDataSourceType(typ)),
+	).Parens(List(Op("*").Id(model), Qual("github.com/hashicorp/terraform-plugin-framework/diag", "Diagnostics"))).Block(
+		Return().Id("encode"+model).Call(
+			Qual("github.com/hashicorp/terraform-plugin-framework/path", "Empty()"),
+			Id(ident),
+		),
+	).Line().Line()
+
+	encoders.Func().Id("encode"+model).Params(
+		Id("path").Qual("github.com/hashicorp/terraform-plugin-framework/path", "Path"),
+		Id(ident).Op("*").Add(listDataSourceType(typ)),
+	).Parens(List(Op("*").Id(model), Qual("github.com/hashicorp/terraform-plugin-framework/diag", "Diagnostics"))).BlockFunc(func(g *Group) {
+		g.If(Id(ident).Op("==").Nil()).Block(
+			Return().List(Nil(), Nil()),
+		).Line()
+		g.Var().Id("diags").Qual("github.com/hashicorp/terraform-plugin-framework/diag", "Diagnostics")
+		g.Id("res").Op(":=").Id(model).Block()
+		g.Add(encodeItems)
+		if hasFilter {
+			g.List(Id("filter"), Id("d")).Op(":=").Id("encode"+filter).Call(
+				Id("path").Dot("AtName").Call(Lit("filter")),
+				Id(ident).Dot("Filter"),
+			)
+			g.Id("diags").Dot("Append").Call(Id("d").Op("..."))
+			g.Id("res").Dot("Filter").Op("=").Id("filter")
+		}
+		g.Return().List(Op("&").Id("res"), Id("diags"))
+	}).Line().Line()
+	if hasFilter {
+		encodeFilter, err := renderEncodeFields(c, typ, filters, filter, "filter", "encode"+filter)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		encoders.Add(encodeFilter)
+	}
+
+	return models, decoders, encoders, nil
+}
@@ -1,8 +1,19 @@
 package generator
 
 import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Lenstra/terraform-plugin-generator/tests/structs"
 	"github.com/dave/jennifer/jen"
@@ -14,10 +25,18 @@ func TestModels(t *testing.T) {
 		"Config":     structs.Config{},
 		"Coffee":     structs.Coffee{},
 		"Ingredient": structs.Ingredient{},
+		"Account":    structs.Account{},
+		"Order":      structs.Order{},
+		"Event":      structs.Event{},
+		"Invoice":    structs.Invoice{},
+		"Limits":     structs.Limits{},
 	}
 	err := GenerateModels("./tests/", "tests", objects, &GeneratorOptions{
-		GetFieldInformation: func(s string, typ reflect.Type, sf reflect.StructField) (*FieldInformation, error) {
-			info, err := GetFieldInformationFromTerraformTag(s, typ, sf)
+		ListDataSources: map[string]interface{}{
+			"coffees": structs.Coffee{},
+		},
+		GetFieldInformation: func(st SchemaType, s string, typ GoType, sf StructField) (*FieldInformation, error) {
+			info, err := GetFieldInformationFromTerraformTag(st, s, typ, sf)
 			if info == nil || err != nil {
 				return info, err
 			}
@@ -33,17 +52,1064 @@ func TestSchema(t *testing.T) {
 		"Config":     structs.Config{},
 		"Coffee":     structs.Coffee{},
 		"Ingredient": structs.Ingredient{},
+		"Account":    structs.Account{},
+		"Order":      structs.Order{},
+		"Event":      structs.Event{},
+		"Invoice":    structs.Invoice{},
+		"Limits":     structs.Limits{},
 	}
 	err := GenerateSchema(ResourceSchema, "./tests/", "tests", objects, &GeneratorOptions{
-		GetFieldInformation: func(s string, typ reflect.Type, sf reflect.StructField) (*FieldInformation, error) {
-			info, err := GetFieldInformationFromTerraformTag(s, typ, sf)
+		GetFieldInformation: func(st SchemaType, s string, typ GoType, sf StructField) (*FieldInformation, error) {
+			info, err := GetFieldInformationFromTerraformTag(st, s, typ, sf)
 			if info == nil || err != nil {
 				return info, err
 			}
 			info.Default = jen.Nil()
 			info.Validators = jen.Nil()
+			// The validators of the tag are added to the ones given by the
+			// getter
+			if typ.Name() == "Limits" && sf.Name == "Retries" {
+				info.Validators = jen.Index().Qual("github.com/hashicorp/terraform-plugin-framework/schema/validator", "Int64").Values(
+					jen.Qual("github.com/hashicorp/terraform-plugin-framework-validators/int64validator", "AtLeast").Call(jen.Lit(-100)),
+				)
+			}
+			// The plan modifiers of the tag are added to the ones given by
+			// the getter
+			if typ.Name() == "Coffee" && sf.Name == "Name" {
+				info.PlanModifiers = jen.Index().Qual("github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier", "String").Values(
+					jen.Qual("github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier", "UseStateForUnknown").Call(),
+				)
+			}
 			return info, nil
 		},
+		UseDocComments: true,
+	})
+	require.NoError(t, err)
+}
+
+func TestSchemaDefaults(t *testing.T) {
+	type Small struct {
+		Value int8 `terraform:"value,default=300"`
+	}
+	type Time struct {
+		Value time.Time `terraform:"value,default=yesterday"`
+	}
+	type Required struct {
+		Value string `terraform:"value,required,default=foo"`
+	}
+	type Object struct {
+		Value structs.Customer `terraform:"value,default={\"unknown\":1}"`
+	}
+	type Empty struct {
+		Value int `terraform:"value,default="`
+	}
+
+	tests := []struct {
+		name   string
+		typ    SchemaType
+		object interface{}
+		err    string
+	}{
+		{"small", ResourceSchema, Small{}, `small.value: invalid default value "300": strconv.ParseInt: parsing "300": value out of range`},
+		{"time", ResourceSchema, Time{}, `time.value: invalid default value "yesterday"`},
+		{"required", ResourceSchema, Required{}, `the default modifier cannot be used on a required attribute`},
+		{"object", ResourceSchema, Object{}, `object.value: invalid default value "{\"unknown\":1}": unknown attribute "unknown"`},
+		{"empty", ResourceSchema, Empty{}, `empty.value: invalid default value ""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := GenerateSchema(tt.typ, t.TempDir(), "tests", map[string]interface{}{tt.name: tt.object}, nil)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestSchemaDefaultsIgnored(t *testing.T) {
+	// The same struct is often used for the resource and its data source, the
+	// default values are ignored in the other schemas
+	for _, typ := range []SchemaType{DataSourceSchema, ProviderSchema} {
+		t.Run(string(typ), func(t *testing.T) {
+			path := t.TempDir()
+			err := GenerateSchema(typ, path, "tests", map[string]interface{}{"coffee": structs.Coffee{}}, nil)
+			require.NoError(t, err)
+			data, err := os.ReadFile(filepath.Join(path, "schema.go"))
+			require.NoError(t, err)
+			schema := string(data)
+
+			require.NotContains(t, schema, "Default")
+		})
+	}
+}
+
+func TestNumberModifier(t *testing.T) {
+	type Signed struct {
+		Value int64 `terraform:"value,number"`
+	}
+	err := GenerateSchema(ResourceSchema, t.TempDir(), "tests", map[string]interface{}{"signed": Signed{}}, nil)
+	require.ErrorContains(t, err, "Signed.Value: the number modifier can only be used on uint64, got int64")
+}
+
+func TestSchemaValidators(t *testing.T) {
+	type Regex struct {
+		Value string `terraform:"value,regex=^[a-z]{1,3}$"`
+	}
+	type InvalidRegex struct {
+		Value string `terraform:"value,regex=^[a-z"`
+	}
+	type Bool struct {
+		Value bool `terraform:"value,min=1"`
+	}
+	type Range struct {
+		Value int `terraform:"value,min=10,max=1"`
+	}
+	type OneOf struct {
+		Value uint8 `terraform:"value,oneof=1|256"`
+	}
+	type List struct {
+		Value []string `terraform:"value,length=1..2"`
+	}
+
+	tests := []struct {
+		name   string
+		object interface{}
+		err    string
+	}{
+		{"regex", Regex{}, ""},
+		{"invalid_regex", InvalidRegex{}, "invalid_regex.value: invalid regex: error parsing regexp: missing closing ]"},
+		{"bool", Bool{}, "bool.value: the min modifier is not supported for bool attributes"},
+		{"range", Range{}, "range.value: min 10 is greater than max 1"},
+		{"oneof", OneOf{}, `oneof.value: invalid oneof value "256"`},
+		{"list", List{}, "list.value: the length modifier is not supported for list attributes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := GenerateSchema(DataSourceSchema, t.TempDir(), "tests", map[string]interface{}{tt.name: tt.object}, nil)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestSchemaPlanModifiers(t *testing.T) {
+	type Object struct {
+		ID   string `terraform:"id,computed,use_state_for_unknown"`
+		Name string `terraform:"name,required,force_new"`
+	}
+
+	path := t.TempDir()
+	err := GenerateSchema(ResourceSchema, path, "tests", map[string]interface{}{"object": Object{}}, nil)
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(path, "schema.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "stringplanmodifier.UseStateForUnknown()")
+	require.Contains(t, string(data), "stringplanmodifier.RequiresReplace()")
+
+	// Plan modifiers are ignored in the other schemas
+	err = GenerateSchema(DataSourceSchema, path, "tests", map[string]interface{}{"object": Object{}}, nil)
+	require.NoError(t, err)
+	data, err = os.ReadFile(filepath.Join(path, "schema.go"))
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "PlanModifiers")
+}
+
+func TestSchemaCrossValidators(t *testing.T) {
+	type Item struct {
+		ID   string `terraform:"id,also_requires=../name"`
+		Name string `terraform:"name,conflicts_with=id"`
+	}
+	type Object struct {
+		Name  string `terraform:"name"`
+		Items []Item `terraform:"items"`
+		Item  *Item  `terraform:"item,at_least_one_of=items"`
+	}
+	type Unknown struct {
+		Name string `terraform:"name,conflicts_with=unknown"`
+	}
+	type Root struct {
+		Name string `terraform:"name,conflicts_with=../name"`
+	}
+	type Nested struct {
+		Name  string `terraform:"name,conflicts_with=items.id"`
+		Items []Item `terraform:"items"`
+	}
+
+	path := t.TempDir()
+	err := GenerateSchema(ResourceSchema, path, "tests", map[string]interface{}{"object": Object{}}, nil)
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(path, "schema.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), `stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtParent().AtParent().AtName("name"))`)
+	require.Contains(t, string(data), `stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("id"))`)
+	require.Contains(t, string(data), `objectvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("items"))`)
+
+	tests := []struct {
+		name   string
+		object interface{}
+		err    string
+	}{
+		{"unknown", Unknown{}, `unknown.name: conflicts_with references unknown attribute "unknown.unknown"`},
+		{"root", Root{}, `root.name: conflicts_with references "../name" which is outside of the schema`},
+		{"nested", Nested{}, `nested.name: conflicts_with cannot reference "nested.items.id" as it is nested in the collection "nested.items"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := GenerateSchema(ResourceSchema, t.TempDir(), "tests", map[string]interface{}{tt.name: tt.object}, nil)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestSchemaJSONTags(t *testing.T) {
+	type Base struct {
+		CreatedAt string `json:"createdAt"`
+	}
+	type Object struct {
+		Base
+		DisplayName string `json:"displayName,omitempty"`
+		Secret      string `json:"-"`
+		Kind        string `json:"kind" terraform:"kind,computed"`
+		Internal    string `terraform:"-"`
+		Count       int
+		private     string
+	}
+
+	path := t.TempDir()
+	getter := FallbackFieldInformationGetter(GetFieldInformationFromTerraformTag, GetFieldInformationFromJSONTag)
+	err := GenerateSchema(ResourceSchema, path, "tests", map[string]interface{}{"object": Object{}}, &GeneratorOptions{
+		GetFieldInformation: getter,
+	})
+	require.NoError(t, err)
+	attributes := schemaAttributes(t, filepath.Join(path, "schema.go"), "objectSchema")
+	require.Equal(t, map[string]string{"Type": "schema.StringAttribute", "Required": "true"}, attributes["created_at"])
+	require.Equal(t, map[string]string{"Type": "schema.StringAttribute", "Optional": "true"}, attributes["display_name"])
+	require.Equal(t, map[string]string{"Type": "schema.StringAttribute", "Computed": "true"}, attributes["kind"])
+	require.Equal(t, map[string]string{"Type": "schema.Int64Attribute", "Required": "true"}, attributes["count"])
+	require.NotContains(t, attributes, "secret")
+	require.NotContains(t, attributes, "internal")
+	require.NotContains(t, attributes, "private")
+}
+
+func TestPromotedCollisions(t *testing.T) {
+	type Audit struct {
+		Name string `terraform:"created_by"`
+	}
+	type Metadata struct {
+		*Audit
+		CreatedBy string `terraform:"created_by"`
+	}
+	type Attribute struct {
+		Metadata
+	}
+	type Other struct {
+		Author string `terraform:"author"`
+	}
+	type GoName struct {
+		Audit
+		Other `terraform:"-,promoted"`
+		Name  string `terraform:"name"`
+	}
+	type Recursive struct {
+		*Recursive
+		Name string `terraform:"name"`
+	}
+
+	tests := []struct {
+		name   string
+		object interface{}
+		err    string
+	}{
+		{"attribute", Attribute{}, `attribute "created_by" of Attribute.Metadata.Audit.Name collides with the one of Attribute.Metadata.CreatedBy`},
+		{"go name", GoName{}, `field GoName.Audit.Name collides with GoName.Name`},
+		{"recursive", Recursive{}, `Recursive.Recursive: generator.Recursive is promoted recursively`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := GenerateSchema(ResourceSchema, t.TempDir(), "tests", map[string]interface{}{tt.name: tt.object}, nil)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestSchemaTypeModes(t *testing.T) {
+	type Object struct {
+		Name  string `terraform:"name,resource:required,datasource:required"`
+		Value string `terraform:"value,required,datasource:computed"`
+		Token string `terraform:"token,provider:optional,provider:computed"`
+	}
+
+	schemas := map[SchemaType]map[string][]string{
+		ResourceSchema:   {"name": {"Required"}, "value": {"Required"}, "token": {"Optional"}},
+		DataSourceSchema: {"name": {"Required"}, "value": {"Computed"}, "token": {"Optional"}},
+		ProviderSchema:   {"name": {"Optional"}, "value": {"Required"}, "token": {"Optional", "Computed"}},
+	}
+	for schemaType, expected := range schemas {
+		t.Run(string(schemaType), func(t *testing.T) {
+			path := t.TempDir()
+			err := GenerateSchema(schemaType, path, "tests", map[string]interface{}{"object": Object{}}, nil)
+			require.NoError(t, err)
+			attributes := schemaAttributes(t, filepath.Join(path, "schema.go"), "objectSchema")
+			for name, modes := range expected {
+				fields := map[string]string{"Type": "schema.StringAttribute"}
+				for _, mode := range modes {
+					fields[mode] = "true"
+				}
+				require.Equal(t, fields, attributes[name], name)
+			}
+		})
+	}
+
+	type UnknownSchema struct {
+		Name string `terraform:"name,function:required"`
+	}
+	type UnknownMode struct {
+		Name string `terraform:"name,datasource:sensitive"`
+	}
+	type Duplicate struct {
+		Name string `terraform:"name,datasource:computed,datasource:computed"`
+	}
+	tests := []struct {
+		name   string
+		object interface{}
+		err    string
+	}{
+		{"unknown schema", UnknownSchema{}, `unknown schema type "function" in modifier "function:required"`},
+		{"unknown mode", UnknownMode{}, `only the optional, required and computed modifiers can be given for a schema type, got "datasource:sensitive"`},
+		{"duplicate", Duplicate{}, `datasource:computed modifier given multiple time`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := GenerateSchema(ResourceSchema, t.TempDir(), "tests", map[string]interface{}{"object": tt.object}, nil)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestDataSourceFromResource(t *testing.T) {
+	type Item struct {
+		Name string `terraform:"name,required,length=1..10"`
+	}
+	type Object struct {
+		Name  string `terraform:"name,required,lookup"`
+		Value string `terraform:"value,default=foo,force_new"`
+		Items []Item `terraform:"items,block"`
+		Count int8   `terraform:"count"`
+	}
+
+	path := t.TempDir()
+	err := GenerateSchema(DataSourceFromResource, path, "tests", map[string]interface{}{
+		"coffee": structs.Coffee{},
+		"object": Object{},
+	}, nil)
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(path, "schema.go"))
+	require.NoError(t, err)
+	schema := string(data)
+
+	require.Contains(t, schema, `"github.com/hashicorp/terraform-plugin-framework/datasource/schema"`)
+	coffee := schemaAttributes(t, filepath.Join(path, "schema.go"), "coffeeSchema")
+	require.Equal(t, "true", coffee["id"]["Optional"])
+	require.Equal(t, "true", coffee["id"]["Computed"])
+	object := schemaAttributes(t, filepath.Join(path, "schema.go"), "objectSchema")
+	require.Equal(t, map[string]string{"Type": "schema.StringAttribute", "Required": "true"}, object["name"])
+	require.Equal(t, map[string]string{"Type": "schema.StringAttribute", "Computed": "true"}, object["value"])
+	require.Equal(t, map[string]string{"Type": "schema.Int64Attribute", "Computed": "true"}, object["count"])
+	require.Equal(t, "schema.ListNestedAttribute", object["items"]["Type"])
+	require.Equal(t, "true", object["items"]["Computed"])
+	require.Equal(t, map[string]string{"Type": "schema.StringAttribute", "Computed": "true"}, object["items.name"])
+	for path, fields := range object {
+		require.NotContains(t, fields["Type"], "Block", path)
+	}
+	require.NotContains(t, schema, "Default")
+	require.NotContains(t, schema, "PlanModifiers")
+	require.NotContains(t, schema, "Validators")
+
+	type NestedLookup struct {
+		Name string `terraform:"name,lookup"`
+	}
+	type Parent struct {
+		Nested NestedLookup `terraform:"nested"`
+	}
+	err = GenerateSchema(DataSourceFromResource, t.TempDir(), "tests", map[string]interface{}{"parent": Parent{}}, nil)
+	require.ErrorContains(t, err, "parent.nested.name: the lookup modifier can only be used on the attributes of the root object")
+}
+
+func TestListDataSource(t *testing.T) {
+	path := t.TempDir()
+	err := GenerateSchema(DataSourceFromResource, path, "tests", map[string]interface{}{"coffee": structs.Coffee{}}, &GeneratorOptions{
+		ListDataSources: map[string]interface{}{
+			"coffees": structs.Coffee{},
+		},
+	})
+	require.NoError(t, err)
+	coffees := schemaAttributes(t, filepath.Join(path, "schema.go"), "coffeesSchema")
+	require.Equal(t, "schema.ListNestedAttribute", coffees["coffees"]["Type"])
+	require.Equal(t, "true", coffees["filter.name"]["Optional"])
+
+	// The lookup keys are only used by the data source of a single object
+	require.Equal(t, map[string]string{"Type": "schema.Int64Attribute", "Computed": "true"}, coffees["coffees.id"])
+
+	type Item struct {
+		Name string `terraform:"name"`
+	}
+	type Nested struct {
+		Item *Item `terraform:"item,filterable"`
+	}
+	tests := []struct {
+		name   string
+		schema SchemaType
+		object interface{}
+		err    string
+	}{
+		{"nested", DataSourceSchema, Nested{}, "nested.filter.item: only the attributes with a simple type can be filterable"},
+		{"filter", DataSourceSchema, Item{}, `filter: a list data source cannot be named "filter"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := GenerateSchema(tt.schema, t.TempDir(), "tests", nil, &GeneratorOptions{
+				ListDataSources: map[string]interface{}{tt.name: tt.object},
+			})
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestDataSources(t *testing.T) {
+	// structs.Customer has no filterable attribute, the list data source has
+	// no filter block
+	options := &GeneratorOptions{
+		ListDataSources: map[string]interface{}{
+			"customers":   structs.Customer{},
+			"coffees":     structs.Coffee{},
+			"ingredients": structs.Ingredient{},
+		},
+		UseDocComments: true,
+	}
+	err := RenderAll(
+		&ModelGenerator{Package: "datasources", Path: "./tests/datasources/", Objects: map[string]interface{}{"Limits": structs.Limits{}}, Options: options},
+		&SchemaGenerator{Type: DataSourceFromResource, Package: "datasources", Path: "./tests/datasources/", Objects: map[string]interface{}{"limits": structs.Limits{}}, Options: options},
+	)
+	require.NoError(t, err)
+}
+
+func TestRenderAll(t *testing.T) {
+	type Invalid struct {
+		Name string `terraform:"name,unknown"`
+	}
+
+	models, resources, datasources := t.TempDir(), t.TempDir(), t.TempDir()
+	err := RenderAll(
+		&ModelGenerator{
+			Package: "models",
+			Path:    models,
+			Objects: map[string]interface{}{"Coffee": structs.Coffee{}},
+		},
+		&SchemaGenerator{
+			Type:    ResourceSchema,
+			Package: "resource",
+			Path:    resources,
+			Objects: map[string]interface{}{"invalid": Invalid{}},
+		},
+		&SchemaGenerator{
+			Type:    DataSourceFromResource,
+			Package: "datasource",
+			Path:    datasources,
+			Objects: map[string]interface{}{"coffee": structs.Coffee{}},
+			Options: &GeneratorOptions{UseDocComments: true},
+		},
+	)
+	require.ErrorContains(t, err, "failed to render the resource schemas in "+resources+`: unknown modifier "unknown"`)
+
+	// The other generators are still rendered
+	for _, path := range []string{
+		filepath.Join(models, "models.go"),
+		filepath.Join(models, "decoders.go"),
+		filepath.Join(models, "encoders.go"),
+		filepath.Join(datasources, "schema.go"),
+	} {
+		require.FileExists(t, path)
+	}
+	data, err := os.ReadFile(filepath.Join(datasources, "schema.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), `MarkdownDescription: "Coffee is a drink served by the coffee shop."`)
+
+	err = (&SchemaGenerator{Type: "unknown", Path: t.TempDir()}).Render()
+	require.ErrorContains(t, err, `unexpected schema type "unknown"`)
+}
+
+func TestSourceLoader(t *testing.T) {
+	const pkgPath = "github.com/Lenstra/terraform-plugin-generator/tests/structs"
+
+	loader := NewSourceLoader("")
+	sourceObjects := map[string]interface{}{}
+	for _, name := range []string{"Config", "Coffee", "Ingredient", "Account", "Order", "Event", "Invoice", "Limits"} {
+		typ, err := loader.Load(pkgPath, name)
+		require.NoError(t, err)
+		sourceObjects[name] = typ
+	}
+	reflectObjects := map[string]interface{}{
+		"Config":     structs.Config{},
+		"Coffee":     structs.Coffee{},
+		"Ingredient": structs.Ingredient{},
+		"Account":    structs.Account{},
+		"Order":      structs.Order{},
+		"Event":      structs.Event{},
+		"Invoice":    structs.Invoice{},
+		"Limits":     structs.Limits{},
+	}
+
+	_, err := loader.Load(pkgPath, "Unknown")
+	require.ErrorContains(t, err, "type Unknown not found in "+pkgPath)
+
+	// The types loaded from the source must render the same files as the ones
+	// found with reflection
+	render := func(objects map[string]interface{}) string {
+		path := t.TempDir()
+		options := &GeneratorOptions{UseDocComments: true}
+		require.NoError(t, RenderAll(
+			&ModelGenerator{Package: "models", Path: path, Objects: objects, Options: options},
+			&SchemaGenerator{Type: DataSourceFromResource, Package: "models", Path: path, Objects: objects, Options: options},
+		))
+		return path
+	}
+	expected, actual := render(reflectObjects), render(sourceObjects)
+	for _, name := range []string{"models.go", "decoders.go", "encoders.go", "schema.go"} {
+		want, err := os.ReadFile(filepath.Join(expected, name))
+		require.NoError(t, err)
+		got, err := os.ReadFile(filepath.Join(actual, name))
+		require.NoError(t, err)
+		require.Equal(t, string(want), string(got), name)
+	}
+}
+
+func TestSourceLoaderPackages(t *testing.T) {
+	const (
+		a = "github.com/Lenstra/terraform-plugin-generator/testdata/source/a"
+		b = "github.com/Lenstra/terraform-plugin-generator/testdata/source/b"
+	)
+
+	// The packages must share their types whatever the order they are loaded
+	// in, package a references a type of package b
+	for _, paths := range [][]string{{a, b}, {b, a}} {
+		loader := NewSourceLoader("")
+		objects := map[string]interface{}{}
+		for _, pkgPath := range paths {
+			name := map[string]string{a: "Order", b: "Customer"}[pkgPath]
+			typ, err := loader.Load(pkgPath, name)
+			require.NoError(t, err)
+			objects[name] = typ
+		}
+		require.Same(t, objects["Customer"], objects["Order"].(GoType).Field(1).Type.Elem())
+
+		path := t.TempDir()
+		require.NoError(t, RenderAll(
+			&ModelGenerator{Package: "models", Path: path, Objects: objects},
+			&SchemaGenerator{Type: ResourceSchema, Package: "models", Path: path, Objects: objects},
+		))
+		data, err := os.ReadFile(filepath.Join(path, "models.go"))
+		require.NoError(t, err)
+		require.Equal(t, 1, strings.Count(string(data), "type Customer struct"))
+		data, err = os.ReadFile(filepath.Join(path, "schema.go"))
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"Customers are now managed by the order resource."`)
+	}
+}
+
+func TestSourceLoaderInvalidTypes(t *testing.T) {
+	const b = "github.com/Lenstra/terraform-plugin-generator/testdata/source/b"
+
+	loader := NewSourceLoader("")
+	_, err := loader.Load(b, "Page")
+	require.ErrorContains(t, err, b+".Page: generic types are not supported")
+
+	// The methods of the types loaded from the source return an invalid type
+	// instead of panicking like reflect, the converters report its error
+	customer, err := loader.Load(b, "Customer")
+	require.NoError(t, err)
+	key := customer.Key()
+	require.Equal(t, reflect.Invalid, key.Kind())
+	require.Equal(t, reflect.Invalid, customer.Field(2).Type.Kind())
+	_, err = NewConverter(DefaultConverters, &map[GoType]string{}, nil, "").Get(key)
+	require.EqualError(t, err, "Key of non-map type b.Customer")
+
+	err = GenerateModels(t.TempDir(), "models", map[string]interface{}{"Customer": customer.Elem()}, nil)
+	require.ErrorContains(t, err, "expected Struct, got invalid type: Elem of invalid type b.Customer")
+}
+
+// legacyConverter is written against the interface using reflect.Type
+type legacyConverter struct{}
+
+type temperature float64
+
+func (legacyConverter) Check(typ reflect.Type) (bool, error) {
+	return typ == reflect.TypeOf(temperature(0)), nil
+}
+
+func (legacyConverter) GetFrameworkType(*Converter, reflect.Type) (*jen.Statement, error) {
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "Float64"), nil
+}
+
+func (legacyConverter) Decode(_ *Converter, _ *FieldInformation, _, src, target *jen.Statement, _ reflect.Type) (*jen.Statement, error) {
+	return target.Op("=").Id("temperature").Call(src.Dot("ValueFloat64").Call()), nil
+}
+
+func (legacyConverter) Encode(_ *Converter, _ *FieldInformation, src, target *jen.Statement, _ reflect.Type) (*jen.Statement, error) {
+	return target.Op("=").Qual("github.com/hashicorp/terraform-plugin-framework/types", "Float64Value").Call(jen.Float64().Call(src)), nil
+}
+
+func (legacyConverter) GetSchema(c *Converter, _ string, info *FieldInformation) (*jen.Statement, *jen.Statement, error) {
+	return basicSchema(c.SchemaImportPath(), "Float64Attribute", info, nil)
+}
+
+func (legacyConverter) GetType() *jen.Statement {
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "Float64Type")
+}
+
+func TestFromReflect(t *testing.T) {
+	converter := NewConverter([]AttributeConverter{FromReflect(legacyConverter{})}, &map[GoType]string{}, nil, "")
+
+	// The converter is used for the types backed by reflect
+	typ := TypeOf(temperature(0))
+	got, err := converter.Get(typ)
+	require.NoError(t, err)
+	require.Implements(t, (*SimpleAttributeConverter)(nil), got)
+	rt, ok := ToReflect(typ)
+	require.True(t, ok)
+	require.Equal(t, reflect.TypeOf(temperature(0)), rt)
+	require.Equal(t, typ, FromReflectType(rt))
+
+	_, err = converter.Encode(&FieldInformation{}, jen.Id("path"), jen.Id("src"), jen.Id("dst"), typ)
+	require.NoError(t, err)
+	_, err = converter.Decode(&FieldInformation{}, jen.Id("path"), jen.Id("src"), jen.Id("dst"), typ)
+	require.NoError(t, err)
+
+	// The types loaded from their source are not supported
+	loader := NewSourceLoader("")
+	customer, err := loader.Load("github.com/Lenstra/terraform-plugin-generator/testdata/source/b", "Customer")
+	require.NoError(t, err)
+	_, ok = ToReflect(customer)
+	require.False(t, ok)
+	_, err = converter.Get(customer.Field(0).Type)
+	require.ErrorContains(t, err, "no converter found for int64")
+}
+
+func TestCheck(t *testing.T) {
+	path := t.TempDir()
+	generator := &ModelGenerator{
+		Package: "models",
+		Path:    path,
+		Objects: map[string]interface{}{"Coffee": structs.Coffee{}},
+		Options: &GeneratorOptions{Check: true},
+	}
+
+	// Nothing is written in check mode
+	err := generator.Render()
+	var stale *StaleFilesError
+	require.ErrorAs(t, err, &stale)
+	require.Len(t, stale.Files, 3)
+	require.NoFileExists(t, filepath.Join(path, "models.go"))
+
+	generator.Options.Check = false
+	require.NoError(t, generator.Render())
+	generator.Options.Check = true
+	require.NoError(t, generator.Render())
+
+	filename := filepath.Join(path, "encoders.go")
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	edited := strings.Replace(string(data), "func ", "func _", 1)
+	require.NoError(t, os.WriteFile(filename, []byte(edited), 0o644))
+
+	err = RenderAll(generator, &SchemaGenerator{
+		Type:    DataSourceFromResource,
+		Package: "models",
+		Path:    path,
+		Objects: map[string]interface{}{"coffee": structs.Coffee{}},
+		Options: &GeneratorOptions{Check: true},
+	})
+	require.ErrorAs(t, err, &stale)
+	require.Equal(t, []string{filename, filepath.Join(path, "schema.go")}, []string{stale.Files[0].Path, stale.Files[1].Path})
+	require.Contains(t, stale.Files[0].Diff, "--- "+filename+"\n+++ "+filename+" (generated)\n")
+	require.Contains(t, stale.Files[0].Diff, "\n-func _")
+	require.Contains(t, err.Error(), "the generated files are not up to date:\n  - "+filename+"\n  - "+filepath.Join(path, "schema.go")+"\n")
+}
+
+func TestOutput(t *testing.T) {
+	output := NewMemoryOutput()
+	options := &GeneratorOptions{
+		Output: output,
+		FileNames: FileNames{
+			Models: "zz_models.go",
+			Schema: "zz_schema.go",
+		},
+	}
+	err := RenderAll(
+		&ModelGenerator{
+			Package: "models",
+			Path:    "models",
+			Objects: map[string]interface{}{"Coffee": structs.Coffee{}},
+			Options: options,
+		},
+		&SchemaGenerator{
+			Type:    DataSourceFromResource,
+			Package: "datasource",
+			Path:    "datasource",
+			Objects: map[string]interface{}{"coffee": structs.Coffee{}},
+			Options: options,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join("datasource", "zz_schema.go"),
+		filepath.Join("models", "decoders.go"),
+		filepath.Join("models", "encoders.go"),
+		filepath.Join("models", "zz_models.go"),
+	}, output.Files())
+	require.NoDirExists(t, "models")
+
+	data, err := output.ReadFile(filepath.Join("datasource", "zz_schema.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "package datasource")
+
+	_, err = output.ReadFile("schema.go")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// The check mode compares the files with the ones of the output
+	options.Check = true
+	err = (&ModelGenerator{
+		Package: "models",
+		Path:    "models",
+		Objects: map[string]interface{}{"Coffee": structs.Coffee{}},
+		Options: options,
+	}).Render()
+	require.NoError(t, err)
+}
+
+func TestAtomicWrites(t *testing.T) {
+	path := t.TempDir()
+	options := (&GeneratorOptions{}).validate()
+
+	valid := jen.NewFile("models")
+	valid.Var().Id("A").Op("=").Lit(1)
+	invalid := jen.NewFile("models")
+	invalid.Var().Id("B").Op("=").Op("}")
+
+	// Nothing is written when one of the files cannot be rendered
+	err := saveFiles(options, path, "models", nil, generatedFile{"a.go", valid}, generatedFile{"b.go", invalid})
+	require.ErrorContains(t, err, "failed to render b.go")
+	entries, err := os.ReadDir(path)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	require.NoError(t, saveFiles(options, path, "models", nil, generatedFile{"a.go", valid}))
+	entries, err = os.ReadDir(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "a.go", entries[0].Name())
+	data, err := os.ReadFile(filepath.Join(path, "a.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "var A = 1")
+
+	// The current files are kept when one of the new ones cannot be written
+	err = OSOutput{}.WriteFiles(map[string][]byte{
+		filepath.Join(path, "a.go"):            []byte("package models\n"),
+		filepath.Join(path, "missing", "b.go"): []byte("package models\n"),
 	})
+	require.Error(t, err)
+	entries, err = os.ReadDir(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	data, err = os.ReadFile(filepath.Join(path, "a.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "var A = 1")
+
+	// The files already replaced are restored when one of them cannot be
+	// replaced, here a directory is in the way
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "b.go", "c"), 0o755))
+	err = OSOutput{}.WriteFiles(map[string][]byte{
+		filepath.Join(path, "a.go"): []byte("package models\n"),
+		filepath.Join(path, "b.go"): []byte("package models\n"),
+	})
+	require.Error(t, err)
+	entries, err = os.ReadDir(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	data, err = os.ReadFile(filepath.Join(path, "a.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "var A = 1")
+}
+
+func TestSplitFiles(t *testing.T) {
+	type Order struct {
+		Customer structs.Customer `terraform:"customer"`
+	}
+	type Invoice struct {
+		Customer structs.Customer `terraform:"customer"`
+	}
+
+	output := NewMemoryOutput()
+	hand := []byte("package models\n\nvar handWritten = true\n")
+	require.NoError(t, output.WriteFile(filepath.Join("models", "extra_models.go"), hand))
+
+	render := func(split bool, objects map[string]interface{}) {
+		options := &GeneratorOptions{
+			Output:          output,
+			SplitFiles:      split,
+			ListDataSources: map[string]interface{}{"coffees": structs.Coffee{}},
+		}
+		err := RenderAll(
+			&ModelGenerator{Package: "models", Path: "models", Objects: objects, Options: options},
+			&SchemaGenerator{Type: DataSourceFromResource, Package: "models", Path: "models", Objects: map[string]interface{}{"coffee": structs.Coffee{}}, Options: options},
+		)
+		require.NoError(t, err)
+	}
+	file := func(name string) string {
+		data, err := output.ReadFile(filepath.Join("models", name))
+		require.NoError(t, err)
+		return string(data)
+	}
+	paths := func(names ...string) []string {
+		files := []string{}
+		for _, name := range names {
+			files = append(files, filepath.Join("models", name))
+		}
+		return files
+	}
+	objects := map[string]interface{}{
+		"Coffee":  structs.Coffee{},
+		"Order":   Order{},
+		"Invoice": Invoice{},
+	}
+
+	render(false, objects)
+	require.Equal(t, paths("decoders.go", "encoders.go", "extra_models.go", "models.go", "schema.go"), output.Files())
+
+	// The schema file is not rendered anymore in split mode and is removed
+	render(true, objects)
+	require.Equal(t, paths(
+		".datasourcefromresource_schema.schema.files", ".models.models.files",
+		"coffee_decoders.go", "coffee_encoders.go", "coffee_models.go", "coffee_schema.go",
+		"coffees_decoders.go", "coffees_encoders.go", "coffees_models.go", "coffees_schema.go",
+		"decoders.go", "encoders.go", "extra_models.go",
+		"invoice_decoders.go", "invoice_encoders.go", "invoice_models.go",
+		"models.go",
+		"order_decoders.go", "order_encoders.go", "order_models.go",
+	), output.Files())
+	require.Equal(t, "# Code generated by github-terraform-generator; DO NOT EDIT.\ncoffee_schema.go\ncoffees_schema.go\n", file(".datasourcefromresource_schema.schema.files"))
+
+	// The types used by a single object are rendered with it, the shared ones
+	// in the common files
+	require.Contains(t, file("coffee_models.go"), "type Coffee struct {")
+	require.Contains(t, file("coffee_models.go"), "type Ingredient struct {")
+	require.Contains(t, file("models.go"), "type Customer struct {")
+	require.Contains(t, file("models.go"), "type ListDataSource[T any] struct {")
+	require.Contains(t, file("decoders.go"), "func decodeCustomer(")
+	require.Contains(t, file("coffees_models.go"), "type CoffeesFilter struct {")
+	require.Contains(t, file("coffees_schema.go"), "func coffeesSchema() schema.Schema {")
+
+	// The check mode reports the files recorded in the manifest that would be
+	// removed
+	delete(objects, "Invoice")
+	err := (&ModelGenerator{
+		Package: "models",
+		Path:    "models",
+		Objects: objects,
+		Options: &GeneratorOptions{Output: output, Check: true, SplitFiles: true, ListDataSources: map[string]interface{}{"coffees": structs.Coffee{}}},
+	}).Render()
+	var stale *StaleFilesError
+	require.ErrorAs(t, err, &stale)
+	stalePaths := []string{}
+	for _, f := range stale.Files {
+		stalePaths = append(stalePaths, f.Path)
+	}
+	require.Equal(t, paths(".models.models.files", "decoders.go", "encoders.go", "invoice_decoders.go", "invoice_encoders.go", "invoice_models.go"), stalePaths)
+	require.Contains(t, stale.Files[3].Diff, "+++ /dev/null")
+
+	// The files of the removed objects are deleted, the files written by hand
+	// and the generated ones that have not been recorded are kept
+	tea := append(bytes.Clone(generatedHeader), "// "+generatorComment("models")+"\n"...)
+	require.NoError(t, output.WriteFile(filepath.Join("models", "tea_models.go"), tea))
+	render(true, objects)
+	require.Equal(t, paths(
+		".datasourcefromresource_schema.schema.files", ".models.models.files",
+		"coffee_decoders.go", "coffee_encoders.go", "coffee_models.go", "coffee_schema.go",
+		"coffees_decoders.go", "coffees_encoders.go", "coffees_models.go", "coffees_schema.go",
+		"decoders.go", "encoders.go", "extra_models.go",
+		"models.go",
+		"order_decoders.go", "order_encoders.go", "order_models.go",
+		"tea_models.go",
+	), output.Files())
+	require.Equal(t, string(hand), file("extra_models.go"))
+
+	// Nothing is removed outside of split mode
+	render(false, objects)
+	require.Contains(t, output.Files(), filepath.Join("models", "coffee_schema.go"))
+	require.Contains(t, file("schema.go"), "func coffeeSchema() schema.Schema {")
+}
+
+func TestSplitFilesSharedDirectory(t *testing.T) {
+	output := NewMemoryOutput()
+	generators := []Generator{
+		&SchemaGenerator{
+			Type:    ResourceSchema,
+			Package: "provider",
+			Path:    "provider",
+			Objects: map[string]interface{}{"coffee": structs.Coffee{}},
+			Options: &GeneratorOptions{Output: output, SplitFiles: true},
+		},
+		&SchemaGenerator{
+			Type:    ResourceSchema,
+			Package: "provider",
+			Path:    "provider",
+			Objects: map[string]interface{}{"latte": structs.Coffee{}},
+			Options: &GeneratorOptions{Output: output, SplitFiles: true, FileNames: FileNames{Schema: "a_schema.go"}},
+		},
+		&SchemaGenerator{
+			Type:    DataSourceSchema,
+			Package: "provider",
+			Path:    "provider",
+			Objects: map[string]interface{}{"coffees": structs.Coffee{}},
+			Options: &GeneratorOptions{Output: output, SplitFiles: true},
+		},
+	}
+	files := []string{
+		filepath.Join("provider", ".datasource_schema.schema.files"),
+		filepath.Join("provider", ".resource_schema.a_schema.files"),
+		filepath.Join("provider", ".resource_schema.schema.files"),
+		filepath.Join("provider", "coffee_schema.go"),
+		filepath.Join("provider", "coffees_schema.go"),
+		filepath.Join("provider", "latte_a_schema.go"),
+	}
+
+	// The generators sharing a directory keep the files of each other, even
+	// when they are of the same kind
+	for i := 0; i < 2; i++ {
+		require.NoError(t, RenderAll(generators...))
+		require.Equal(t, files, output.Files())
+	}
+
+	for _, g := range generators {
+		g.(*SchemaGenerator).Options.Check = true
+	}
+	require.NoError(t, RenderAll(generators...))
+}
+
+// writeOnlyOutput is an Output that does not implement RemovableOutput
+type writeOnlyOutput struct {
+	output *MemoryOutput
+}
+
+func (o writeOnlyOutput) ReadFile(path string) ([]byte, error) {
+	return o.output.ReadFile(path)
+}
+
+func (o writeOnlyOutput) WriteFile(path string, data []byte) error {
+	return o.output.WriteFile(path, data)
+}
+
+func TestSplitFilesWriteOnlyOutput(t *testing.T) {
+	output := NewMemoryOutput()
+	render := func(split bool) {
+		err := (&SchemaGenerator{
+			Type:    ResourceSchema,
+			Package: "models",
+			Path:    "models",
+			Objects: map[string]interface{}{"coffee": structs.Coffee{}},
+			Options: &GeneratorOptions{Output: writeOnlyOutput{output}, SplitFiles: split},
+		}).Render()
+		require.NoError(t, err)
+	}
+
+	// The obsolete files are kept when the output cannot remove them
+	render(false)
+	render(true)
+	require.Equal(t, []string{
+		filepath.Join("models", "coffee_schema.go"),
+		filepath.Join("models", "schema.go"),
+	}, output.Files())
+}
+
+func TestTimeTypes(t *testing.T) {
+	options := &GeneratorOptions{
+		AttributeConverters: slices.Concat(TimeTypesConverters, DefaultConverters),
+		UseDocComments:      true,
+	}
+	objects := map[string]interface{}{
+		"Brewing": structs.Brewing{},
+	}
+	err := RenderAll(
+		&ModelGenerator{Package: "timetests", Path: "./tests/timetests/", Objects: objects, Options: options},
+		&SchemaGenerator{Type: ResourceSchema, Package: "timetests", Path: "./tests/timetests/", Objects: objects, Options: options},
+	)
+	require.NoError(t, err)
+
+	// The times and durations stay strings without the converters
+	output := NewMemoryOutput()
+	err = (&ModelGenerator{
+		Package: "timetests",
+		Path:    "timetests",
+		Objects: objects,
+		Options: &GeneratorOptions{Output: output},
+	}).Render()
+	require.NoError(t, err)
+	data, err := output.ReadFile(filepath.Join("timetests", "models.go"))
+	require.NoError(t, err)
+	require.Regexp(t, `StartedAt\s+types\.String`, string(data))
+}
+
+// schemaAttributes parses the schema rendered by function in filename and
+// returns the fields of its attributes and blocks, indexed by their path, e.g.
+// "items.name", so that the tests do not depend on the formatting of the file
+func schemaAttributes(t *testing.T, filename, function string) map[string]map[string]string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
 	require.NoError(t, err)
+
+	attributes := map[string]map[string]string{}
+	compositeLit := func(expr ast.Expr) *ast.CompositeLit {
+		if unary, ok := expr.(*ast.UnaryExpr); ok {
+			expr = unary.X
+		}
+		lit, _ := expr.(*ast.CompositeLit)
+		return lit
+	}
+	var walk func(prefix string, lit *ast.CompositeLit)
+	walk = func(prefix string, lit *ast.CompositeLit) {
+		for _, elt := range lit.Elts {
+			field, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			switch key := types.ExprString(field.Key); key {
+			case "NestedObject":
+				walk(prefix, compositeLit(field.Value))
+			case "Attributes", "Blocks":
+				for _, elt := range compositeLit(field.Value).Elts {
+					entry := elt.(*ast.KeyValueExpr)
+					name, err := strconv.Unquote(entry.Key.(*ast.BasicLit).Value)
+					require.NoError(t, err)
+					attribute := compositeLit(entry.Value)
+					path := strings.TrimPrefix(prefix+"."+name, ".")
+					attributes[path] = map[string]string{"Type": types.ExprString(attribute.Type)}
+					walk(path, attribute)
+				}
+			default:
+				if prefix != "" {
+					attributes[prefix][key] = types.ExprString(field.Value)
+				}
+			}
+		}
+	}
+
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == function {
+			walk("", compositeLit(fn.Body.List[0].(*ast.ReturnStmt).Results[0]))
+			return attributes
+		}
+	}
+	require.FailNow(t, "schema not found", function)
+	return nil
 }
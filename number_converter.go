@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// NumberConverter knows how to convert *big.Int, *big.Float, big.Int and
+// json.Number to types.Number so that the values keep their precision. The
+// uint64 and *uint64 fields using the number modifier are also converted to
+// types.Number since an Int64 cannot hold the values above math.MaxInt64.
+type NumberConverter struct{}
+
+var _ AttributeConverter = &NumberConverter{}
+
+// numberType is the type of the fields using the number modifier, it makes
+// the NumberConverter handle them instead of the IntConverter
+type numberType struct {
+	GoType
+}
+
+func (c *NumberConverter) Check(typ GoType) (bool, error) {
+	return numberKind(typ) != "", nil
+}
+
+// numberKind returns the name of the Go type converted to types.Number, it is
+// empty when typ is not supported
+func numberKind(typ GoType) string {
+	if _, ok := typ.(*numberType); ok {
+		return "uint64"
+	}
+	if isNamedType(typ, "encoding/json", "Number") {
+		return "json.Number"
+	}
+	if typ.Kind() == reflect.Pointer {
+		switch elem := typ.Elem(); {
+		case isNamedType(elem, "math/big", "Int"):
+			return "*big.Int"
+		case isNamedType(elem, "math/big", "Float"):
+			return "*big.Float"
+		}
+		return ""
+	}
+	if isNamedType(typ, "math/big", "Int") {
+		return "big.Int"
+	}
+	return ""
+}
+
+// isBigNumber returns whether typ is one of the structs of math/big converted
+// to types.Number, they have no model
+func isBigNumber(typ GoType) bool {
+	return isNamedType(typ, "math/big", "Int") || isNamedType(typ, "math/big", "Float")
+}
+
+func (c *NumberConverter) GetFrameworkType(_ *Converter, typ GoType) (*jen.Statement, error) {
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "Number"), nil
+}
+
+func (c *NumberConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	invalid := func(format string) *jen.Statement {
+		return jen.Id("diags").Dot("Append").Call(jen.Qual("github.com/hashicorp/terraform-plugin-framework/diag", "NewAttributeErrorDiagnostic").Call(
+			path.Clone(),
+			jen.Lit("invalid number"),
+			jen.Qual("fmt", "Sprintf").Call(jen.Lit(format), jen.Id("f").Dot("Text").Call(jen.LitRune('g'), jen.Lit(-1))),
+		))
+	}
+
+	var code *jen.Statement
+	switch kind := numberKind(typ); kind {
+	case "*big.Int", "big.Int":
+		op := jen.Empty()
+		if kind == "big.Int" {
+			op = jen.Op("*")
+		}
+		code = jen.If(jen.Op("!").Id("f").Dot("IsInt").Call()).Block(
+			invalid("%s is not an integer"),
+		).Else().Block(
+			jen.List(jen.Id("n"), jen.Id("_")).Op(":=").Id("f").Dot("Int").Call(jen.Nil()),
+			target.Clone().Op("=").Add(op).Id("n"),
+		)
+	case "*big.Float":
+		code = target.Clone().Op("=").New(jen.Qual("math/big", "Float")).Dot("Copy").Call(jen.Id("f"))
+	case "json.Number":
+		// The numbers are rendered without an exponent to keep them as they
+		// were usually encoded
+		code = target.Clone().Op("=").Qual("encoding/json", "Number").Call(jen.Id("f").Dot("Text").Call(jen.LitRune('f'), jen.Lit(-1)))
+	case "uint64":
+		op := jen.Empty()
+		if typ.Kind() == reflect.Pointer {
+			op = jen.Op("&")
+		}
+		code = jen.If(
+			jen.List(jen.Id("n"), jen.Id("accuracy")).Op(":=").Id("f").Dot("Uint64").Call(),
+			jen.Id("accuracy").Op("!=").Qual("math/big", "Exact"),
+		).Block(
+			invalid("%s does not fit in a uint64"),
+		).Else().Block(
+			target.Clone().Op("=").Add(op).Id("n"),
+		)
+	default:
+		return nil, fmt.Errorf("unexpected type %s", typ.String())
+	}
+
+	// ValueBigFloat returns nil for the null and unknown values
+	return jen.If(
+		jen.Id("f").Op(":=").Add(src.Clone()).Dot("ValueBigFloat").Call(),
+		jen.Id("f").Op("!=").Nil(),
+	).Block(code), nil
+}
+
+func (c *NumberConverter) Encode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	numberValue := func(value jen.Code) *jen.Statement {
+		return target.Clone().Op("=").Qual("github.com/hashicorp/terraform-plugin-framework/types", "NumberValue").Call(value)
+	}
+	newFloat := jen.New(jen.Qual("math/big", "Float"))
+
+	switch numberKind(typ) {
+	case "*big.Int":
+		return jen.If(src.Clone().Op("!=").Nil()).Block(
+			numberValue(newFloat.Dot("SetInt").Call(src.Clone())),
+		), nil
+	case "big.Int":
+		return numberValue(newFloat.Dot("SetInt").Call(jen.Op("&").Add(src.Clone()))), nil
+	case "*big.Float":
+		return jen.If(src.Clone().Op("!=").Nil()).Block(
+			numberValue(newFloat.Dot("Copy").Call(src.Clone())),
+		), nil
+	case "json.Number":
+		return jen.If(src.Clone().Op("!=").Lit("")).Block(
+			jen.List(jen.Id("f"), jen.Id("_"), jen.Id("err")).Op(":=").Qual("math/big", "ParseFloat").Call(
+				jen.String().Call(src.Clone()), jen.Lit(10), jen.Lit(512), jen.Qual("math/big", "ToNearestEven"),
+			),
+			jen.If(jen.Id("err").Op("!=").Nil()).Block(
+				jen.Id("diags").Dot("Append").Call(jen.Qual("github.com/hashicorp/terraform-plugin-framework/diag", "NewAttributeErrorDiagnostic").Call(
+					path.Clone(),
+					jen.Lit("invalid number"),
+					jen.Id("err").Dot("Error").Call(),
+				)),
+			).Else().Block(
+				numberValue(jen.Id("f")),
+			),
+		), nil
+	case "uint64":
+		if typ.Kind() == reflect.Pointer {
+			return jen.If(src.Clone().Op("!=").Nil()).Block(
+				numberValue(newFloat.Dot("SetUint64").Call(jen.Op("*").Add(src.Clone()))),
+			), nil
+		}
+		return numberValue(newFloat.Dot("SetUint64").Call(src.Clone())), nil
+	}
+	return nil, fmt.Errorf("unexpected type %s", typ.String())
+}
+
+func (c *NumberConverter) GetSchema(converters *Converter, path string, info *FieldInformation) (*jen.Statement, *jen.Statement, error) {
+	return basicSchema(converters.SchemaImportPath(), "NumberAttribute", info, nil)
+}
+
+func (c *NumberConverter) GetType() *jen.Statement {
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "NumberType")
+}
+
+func (c *NumberConverter) GetAttributeKind(_ *FieldInformation) string {
+	return "Number"
+}
+
+func (c *NumberConverter) GetValidators(_ *Converter, _ *FieldInformation, constraints []Constraint) ([]jen.Code, error) {
+	if len(constraints) != 0 {
+		return nil, unsupportedConstraint(constraints[0], "Number")
+	}
+	return nil, nil
+}
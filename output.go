@@ -0,0 +1,478 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/stoewer/go-strcase"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// Output is where the generators write the files they render
+type Output interface {
+	// ReadFile returns the content of the file at path, the error must wrap
+	// fs.ErrNotExist when it does not exist
+	ReadFile(path string) ([]byte, error)
+	// WriteFile creates or replaces the file at path
+	WriteFile(path string, data []byte) error
+}
+
+// BatchOutput is implemented by the outputs that can write several files at
+// once, the generators use it to replace all their files or none of them
+type BatchOutput interface {
+	Output
+	// WriteFiles creates or replaces the files, indexed by their path
+	WriteFiles(files map[string][]byte) error
+}
+
+// RemovableOutput is implemented by the outputs that can remove their files,
+// the generators use it to remove the files they do not render anymore
+type RemovableOutput interface {
+	Output
+	// Remove removes the file at path
+	Remove(path string) error
+}
+
+// OSOutput writes the files on disk, it is the default Output
+type OSOutput struct{}
+
+var (
+	_ BatchOutput     = OSOutput{}
+	_ RemovableOutput = OSOutput{}
+)
+
+func (OSOutput) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+// WriteFile writes data in a temporary file that replaces the one at path
+// once it is complete, so the file is never partially written
+func (o OSOutput) WriteFile(path string, data []byte) error {
+	return o.WriteFiles(map[string][]byte{path: data})
+}
+
+// WriteFiles writes all the files in temporary files before replacing the
+// current ones. The current files are moved to backups while they are
+// replaced and restored when one of them cannot be replaced, so a failure
+// leaves the current files untouched.
+func (OSOutput) WriteFiles(files map[string][]byte) (err error) {
+	paths := maps.Keys(files)
+	slices.Sort(paths)
+
+	temps := map[string]string{}
+	backups := map[string]string{}
+	replaced := []string{}
+	defer func() {
+		for _, tmp := range temps {
+			os.Remove(tmp)
+		}
+		if err == nil {
+			for _, backup := range backups {
+				os.Remove(backup)
+			}
+			return
+		}
+		for i := len(replaced) - 1; i >= 0; i-- {
+			path := replaced[i]
+			if backup, found := backups[path]; found {
+				os.Rename(backup, path)
+			} else {
+				os.Remove(path)
+			}
+		}
+	}()
+
+	for _, path := range paths {
+		tmp, err := writeTemp(path, files[path])
+		if err != nil {
+			return err
+		}
+		temps[path] = tmp
+	}
+	for _, path := range paths {
+		backup, err := backupFile(path)
+		if err != nil {
+			return err
+		}
+		if backup != "" {
+			backups[path] = backup
+		}
+		if err := os.Rename(temps[path], path); err != nil {
+			// The backup is restored with the files already replaced
+			if backup != "" {
+				if err := os.Rename(backup, path); err == nil {
+					delete(backups, path)
+				}
+			}
+			return err
+		}
+		delete(temps, path)
+		replaced = append(replaced, path)
+	}
+	return nil
+}
+
+// backupFile moves the file at path to a backup next to it and returns its
+// name, it is empty when the file does not exist
+func backupFile(path string) (string, error) {
+	if _, err := os.Lstat(path); errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	backup, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.bak")
+	if err != nil {
+		return "", err
+	}
+	if err := backup.Close(); err != nil {
+		os.Remove(backup.Name())
+		return "", err
+	}
+	if err := os.Rename(path, backup.Name()); err != nil {
+		os.Remove(backup.Name())
+		return "", err
+	}
+	return backup.Name(), nil
+}
+
+// writeTemp writes data in a temporary file next to path and returns its name
+func writeTemp(path string, data []byte) (_ string, err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return "", err
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+func (OSOutput) Remove(path string) error {
+	return os.Remove(path)
+}
+
+// MemoryOutput keeps the files in memory, e.g. to inspect them in tests or to
+// post-process them before saving them
+type MemoryOutput struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+var (
+	_ BatchOutput     = &MemoryOutput{}
+	_ RemovableOutput = &MemoryOutput{}
+)
+
+func NewMemoryOutput() *MemoryOutput {
+	return &MemoryOutput{files: map[string][]byte{}}
+}
+
+func (o *MemoryOutput) ReadFile(path string) ([]byte, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	data, found := o.files[filepath.Clean(path)]
+	if !found {
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	return bytes.Clone(data), nil
+}
+
+func (o *MemoryOutput) WriteFile(path string, data []byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.files[filepath.Clean(path)] = bytes.Clone(data)
+	return nil
+}
+
+func (o *MemoryOutput) WriteFiles(files map[string][]byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for path, data := range files {
+		o.files[filepath.Clean(path)] = bytes.Clone(data)
+	}
+	return nil
+}
+
+func (o *MemoryOutput) Remove(path string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, found := o.files[filepath.Clean(path)]; !found {
+		return &fs.PathError{Op: "remove", Path: path, Err: fs.ErrNotExist}
+	}
+	delete(o.files, filepath.Clean(path))
+	return nil
+}
+
+// Files returns the paths of the files that have been written, sorted
+func (o *MemoryOutput) Files() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	files := maps.Keys(o.files)
+	slices.Sort(files)
+	return files
+}
+
+// FileNames are the names of the files rendered by the generators
+type FileNames struct {
+	Models   string
+	Decoders string
+	Encoders string
+	Schema   string
+}
+
+// DefaultFileNames are the file names used when they are not set in the
+// GeneratorOptions
+var DefaultFileNames = FileNames{
+	Models:   "models.go",
+	Decoders: "decoders.go",
+	Encoders: "encoders.go",
+	Schema:   "schema.go",
+}
+
+func (n FileNames) withDefaults() FileNames {
+	if n.Models == "" {
+		n.Models = DefaultFileNames.Models
+	}
+	if n.Decoders == "" {
+		n.Decoders = DefaultFileNames.Decoders
+	}
+	if n.Encoders == "" {
+		n.Encoders = DefaultFileNames.Encoders
+	}
+	if n.Schema == "" {
+		n.Schema = DefaultFileNames.Schema
+	}
+	return n
+}
+
+// generatedFile is a file rendered by a generator, file is nil when there is
+// nothing to render
+type generatedFile struct {
+	name string
+	file *jen.File
+}
+
+// generatedHeader starts the generated files, jen renders the multi-line
+// header comments as a block comment. It is checked before removing an
+// obsolete file so that the files edited by hand are kept.
+var generatedHeader = []byte("/*\n" + headerComment + "\n*/\n")
+
+// generatorComment follows the header of the generated files and names the
+// generator that rendered them, e.g. "resource schema", so that the generators
+// sharing a directory do not remove the files of each other
+func generatorComment(generator string) string {
+	return "Generator: " + generator
+}
+
+// manifestName returns the name of the file listing the files rendered by
+// generator in split mode, base is the name of its first file so that two
+// generators of the same kind can share a directory
+func manifestName(generator, base string) string {
+	return "." + strings.ReplaceAll(generator, " ", "_") + "." + strings.TrimSuffix(base, ".go") + ".files"
+}
+
+// renderManifest lists the names of the rendered files, one per line
+func renderManifest(rendered map[string][]byte) []byte {
+	names := maps.Keys(rendered)
+	slices.Sort(names)
+	return []byte("# " + strings.SplitN(headerComment, "\n", 2)[0] + "\n" + strings.Join(names, "\n") + "\n")
+}
+
+// objectFileName returns the name of the file of the object name in split
+// mode, base is the name of the file of all the objects
+func objectFileName(name, base string) string {
+	return strcase.SnakeCase(name) + "_" + base
+}
+
+// checkObjectFileName checks that the object name does not share its files
+// with another one, seen is updated with its name
+func checkObjectFileName(seen map[string]string, name string) error {
+	prefix := strcase.SnakeCase(name)
+	if other, found := seen[prefix]; found {
+		return fmt.Errorf("%s and %s cannot be rendered in separate files since their names are too close", other, name)
+	}
+	seen[prefix] = name
+	return nil
+}
+
+// saveFiles writes the files in path, or compares them with the current ones
+// in check mode. All the files are rendered before the first one is written so
+// that a rendering failure does not leave a mismatched set of files.
+//
+// In split mode, when the output implements RemovableOutput, the names of the
+// rendered files are recorded in a manifest. The files of bases and those
+// recorded by the previous run that are not part of files anymore are removed
+// once the new files have been written, a failure only leaves obsolete files
+// that the next run removes.
+func saveFiles(opts *GeneratorOptions, path, generator string, bases []string, files ...generatedFile) error {
+	rendered := map[string][]byte{}
+	for _, f := range files {
+		if f.file == nil {
+			continue
+		}
+		f.file.HeaderComment(generatorComment(generator))
+		var buf bytes.Buffer
+		if err := f.file.Render(&buf); err != nil {
+			return fmt.Errorf("failed to render %s: %w", f.name, err)
+		}
+		// jen formats the files with gofmt while rendering them
+		rendered[f.name] = buf.Bytes()
+	}
+
+	obsolete := []string{}
+	if _, ok := opts.Output.(RemovableOutput); ok && opts.SplitFiles {
+		manifest := manifestName(generator, bases[0])
+		var err error
+		obsolete, err = obsoleteFiles(opts.Output, path, generator, manifest, bases, rendered)
+		if err != nil {
+			return err
+		}
+		rendered[manifest] = renderManifest(rendered)
+	}
+
+	if !opts.Check {
+		if err := writeFiles(opts.Output, path, rendered); err != nil {
+			return err
+		}
+		for _, name := range obsolete {
+			if err := opts.Output.(RemovableOutput).Remove(filepath.Join(path, name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	names := maps.Keys(rendered)
+	slices.Sort(names)
+	stale := &StaleFilesError{}
+	for _, name := range names {
+		data := rendered[name]
+		filename := filepath.Join(path, name)
+		current, err := opts.Output.ReadFile(filename)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if bytes.Equal(current, data) {
+			continue
+		}
+		diff, err := unifiedDiff(filename, current, filename+" (generated)", data)
+		if err != nil {
+			return err
+		}
+		stale.Files = append(stale.Files, StaleFile{Path: filename, Diff: diff})
+	}
+	for _, name := range obsolete {
+		filename := filepath.Join(path, name)
+		current, err := opts.Output.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		diff, err := unifiedDiff(filename, current, "/dev/null", nil)
+		if err != nil {
+			return err
+		}
+		stale.Files = append(stale.Files, StaleFile{Path: filename, Diff: diff})
+	}
+
+	if len(stale.Files) != 0 {
+		return stale
+	}
+	return nil
+}
+
+// writeFiles writes the rendered files in path, they are written at once when
+// the output supports it
+func writeFiles(output Output, path string, rendered map[string][]byte) error {
+	files := map[string][]byte{}
+	for name, data := range rendered {
+		files[filepath.Join(path, name)] = data
+	}
+	if batch, ok := output.(BatchOutput); ok {
+		return batch.WriteFiles(files)
+	}
+
+	paths := maps.Keys(files)
+	slices.Sort(paths)
+	for _, filename := range paths {
+		if err := output.WriteFile(filename, files[filename]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// obsoleteFiles returns the names of the files of path that have been
+// rendered by generator in a previous run and that are not rendered anymore:
+// the ones named after bases and the ones recorded in the manifest
+func obsoleteFiles(output Output, path, generator, manifest string, bases []string, rendered map[string][]byte) ([]string, error) {
+	names := slices.Clone(bases)
+	data, err := output.ReadFile(filepath.Join(path, manifest))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" || strings.HasPrefix(line, "#") || filepath.Base(line) != line {
+			continue
+		}
+		if !slices.Contains(names, line) {
+			names = append(names, line)
+		}
+	}
+	slices.Sort(names)
+
+	header := append(bytes.Clone(generatedHeader), "// "+generatorComment(generator)+"\n"...)
+	obsolete := []string{}
+	for _, name := range names {
+		if _, found := rendered[name]; found {
+			continue
+		}
+		// The files removed or edited by hand, and the ones rendered by
+		// another generator, are skipped
+		data, err := output.ReadFile(filepath.Join(path, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if bytes.HasPrefix(data, header) {
+			obsolete = append(obsolete, name)
+		}
+	}
+	return obsolete, nil
+}
+
+func unifiedDiff(fromFile string, from []byte, toFile string, to []byte) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(from)),
+		B:        difflib.SplitLines(string(to)),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+const (
+	resourceSchemaImportPath = "github.com/hashicorp/terraform-plugin-framework/resource/schema/"
+	planModifierImportPath   = resourceSchemaImportPath + "planmodifier"
+)
+
+// planModifiers maps the plan modifiers that can be given in the tag to the
+// function of the framework packages
+var planModifiers = map[string]string{
+	"force_new":                      "RequiresReplace",
+	"requires_replace_if_configured": "RequiresReplaceIfConfigured",
+	"use_state_for_unknown":          "UseStateForUnknown",
+}
+
+// resolvePlanModifiers converts the plan modifiers found in the tag to the
+// framework plan modifiers, they are only used in resource schemas
+func (c *Converter) resolvePlanModifiers(info *FieldInformation) error {
+	if c.schemaType != ResourceSchema {
+		// The same type is often used for the resource and its data source
+		// so we ignore the plan modifiers instead of returning an error
+		info.PlanModifiers = nil
+		return nil
+	}
+	if len(info.PlanModifierNames) == 0 {
+		return nil
+	}
+
+	converter, err := c.Get(info.goType)
+	if err != nil {
+		return err
+	}
+	kindConverter, ok := converter.(AttributeKindConverter)
+	if !ok {
+		return fmt.Errorf("%s: %T does not support plan modifiers", info.Path, converter)
+	}
+
+	kind := kindConverter.GetAttributeKind(info)
+	importPath := resourceSchemaImportPath + strings.ToLower(kind) + "planmodifier"
+
+	codes := []jen.Code{}
+	for _, name := range info.PlanModifierNames {
+		function, found := planModifiers[name]
+		if !found {
+			return fmt.Errorf("%s: unknown plan modifier %q", info.Path, name)
+		}
+		codes = append(codes, jen.Qual(importPath, function).Call())
+	}
+
+	if info.PlanModifiers != nil {
+		// The plan modifiers set by the FieldInformationGetter are kept, they
+		// are converted since they can be an untyped nil
+		existing := info.PlanModifiers
+		info.PlanModifiers = jen.AppendFunc(func(g *jen.Group) {
+			g.Index().Qual(planModifierImportPath, kind).Parens(existing)
+			for _, code := range codes {
+				g.Line().Add(code)
+			}
+			g.Line()
+		})
+		return nil
+	}
+	info.PlanModifiers = jen.Index().Qual(planModifierImportPath, kind).ValuesFunc(func(g *jen.Group) {
+		for _, code := range codes {
+			g.Line().Add(code)
+		}
+		g.Line()
+	})
+	return nil
+}
@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// ReflectAttributeConverter is the interface of the attribute converters
+// written before the converters used GoType, FromReflect adapts them to
+// AttributeConverter
+type ReflectAttributeConverter interface {
+	Check(reflect.Type) (bool, error)
+	GetFrameworkType(*Converter, reflect.Type) (*jen.Statement, error)
+	Decode(*Converter, *FieldInformation, *jen.Statement, *jen.Statement, *jen.Statement, reflect.Type) (*jen.Statement, error)
+	Encode(*Converter, *FieldInformation, *jen.Statement, *jen.Statement, reflect.Type) (*jen.Statement, error)
+	GetSchema(*Converter, string, *FieldInformation) (*jen.Statement, *jen.Statement, error)
+}
+
+// FromReflect adapts an attribute converter using reflect.Type. It is only
+// used for the types backed by reflect, see ToReflect, so it does not support
+// the types loaded by a SourceLoader, and its Encode method does not receive
+// the path of the attribute. The converter can call the methods of Converter
+// with FromReflectType.
+func FromReflect(converter ReflectAttributeConverter) AttributeConverter {
+	if _, ok := converter.(SimpleAttributeConverter); ok {
+		return &simpleReflectConverter{reflectConverter{converter}}
+	}
+	return &reflectConverter{converter}
+}
+
+// ToReflect returns the reflect.Type of typ, it is only found for the types
+// backed by reflect, e.g. the ones returned by TypeOf
+func ToReflect(typ GoType) (reflect.Type, bool) {
+	t, ok := typ.(reflectType)
+	if !ok {
+		return nil, false
+	}
+	return t.typ, true
+}
+
+// FromReflectType returns the GoType of typ
+func FromReflectType(typ reflect.Type) GoType {
+	return reflectTypeOf(typ)
+}
+
+type reflectConverter struct {
+	converter ReflectAttributeConverter
+}
+
+var _ AttributeConverter = &reflectConverter{}
+
+func toReflect(typ GoType) (reflect.Type, error) {
+	t, ok := ToReflect(typ)
+	if !ok {
+		return nil, fmt.Errorf("%s is not backed by reflect", typ)
+	}
+	return t, nil
+}
+
+func (c *reflectConverter) Check(typ GoType) (bool, error) {
+	t, ok := ToReflect(typ)
+	if !ok {
+		return false, nil
+	}
+	return c.converter.Check(t)
+}
+
+func (c *reflectConverter) GetFrameworkType(converters *Converter, typ GoType) (*jen.Statement, error) {
+	t, err := toReflect(typ)
+	if err != nil {
+		return nil, err
+	}
+	return c.converter.GetFrameworkType(converters, t)
+}
+
+func (c *reflectConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	t, err := toReflect(typ)
+	if err != nil {
+		return nil, err
+	}
+	return c.converter.Decode(converters, field, path, src, target, t)
+}
+
+func (c *reflectConverter) Encode(converters *Converter, field *FieldInformation, _, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	t, err := toReflect(typ)
+	if err != nil {
+		return nil, err
+	}
+	return c.converter.Encode(converters, field, src, target, t)
+}
+
+func (c *reflectConverter) GetSchema(converters *Converter, path string, info *FieldInformation) (*jen.Statement, *jen.Statement, error) {
+	return c.converter.GetSchema(converters, path, info)
+}
+
+// simpleReflectConverter adapts the converters implementing
+// SimpleAttributeConverter
+type simpleReflectConverter struct {
+	reflectConverter
+}
+
+var _ SimpleAttributeConverter = &simpleReflectConverter{}
+
+func (c *simpleReflectConverter) GetType() *jen.Statement {
+	return c.converter.(SimpleAttributeConverter).GetType()
+}
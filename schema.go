@@ -2,7 +2,6 @@ package generator
 
 import (
 	"fmt"
-	"path/filepath"
 	"reflect"
 	"sort"
 
@@ -18,13 +17,25 @@ var (
 	DataSourceSchema   SchemaType = "datasource"
 	ResourceSchema     SchemaType = "resource"
 	ProviderMetaSchema SchemaType = "providermeta"
+
+	// DataSourceFromResource renders a data source schema from a type tagged
+	// for a resource, see dataSourceField
+	DataSourceFromResource SchemaType = "datasourcefromresource"
 )
 
+func isSchemaType(s string) bool {
+	switch SchemaType(s) {
+	case ProviderSchema, DataSourceSchema, ResourceSchema, ProviderMetaSchema:
+		return true
+	}
+	return false
+}
+
 func (s SchemaType) importPath() string {
 	switch s {
 	case ProviderSchema:
 		return "github.com/hashicorp/terraform-plugin-framework/provider/schema"
-	case DataSourceSchema:
+	case DataSourceSchema, DataSourceFromResource:
 		return "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	case ResourceSchema:
 		return "github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -36,38 +47,121 @@ func (s SchemaType) importPath() string {
 
 func GenerateSchema(typ SchemaType, path, pkg string, objects map[string]interface{}, opts *GeneratorOptions) error {
 	opts = opts.validate()
+	m := map[GoType]string{}
+	converter := NewConverter(opts.AttributeConverters, &m, opts.GetFieldInformation, typ)
+	return generateSchema(converter, typ, path, pkg, objects, opts)
+}
+
+// generateSchema renders the schemas using converter, its naming table can be
+// shared with other generators
+func generateSchema(converter *Converter, typ SchemaType, path, pkg string, objects map[string]interface{}, opts *GeneratorOptions) error {
 	importPath := typ.importPath()
 	if importPath == "" {
 		return fmt.Errorf("unexpected schema type %q", typ)
 	}
+	converter.configure(typ, opts)
+	opts.Logger.Debug("generating schemas", "type", typ, "path", path)
 
 	names := []string{}
 	for k := range objects {
 		names = append(names, k)
 	}
 
-	m := map[reflect.Type]string{}
-	converter := NewConverter(opts.AttributeConverters, &m, opts.GetFieldInformation, importPath)
-
 	sort.Strings(names)
 
-	f := NewFile(pkg)
-	f.HeaderComment(headerComment)
+	f := newFile(pkg)
+	// In split mode each object has its own file, the common one is not
+	// rendered
+	generated := []generatedFile{{opts.FileNames.Schema, f}}
+	if opts.SplitFiles {
+		generated[0].file = nil
+	}
+	fileNames := map[string]string{}
+	fileFor := func(name string) (*File, error) {
+		if !opts.SplitFiles {
+			return f, nil
+		}
+		if err := checkObjectFileName(fileNames, name); err != nil {
+			return nil, err
+		}
+		file := newFile(pkg)
+		generated = append(generated, generatedFile{objectFileName(name, opts.FileNames.Schema), file})
+		return file, nil
+	}
 
 	for _, name := range names {
-		code, err := renderObjectSchema(converter, importPath, name, reflect.TypeOf(objects[name]), opts)
+		code, err := renderObjectSchema(converter, importPath, name, TypeOf(objects[name]), opts)
+		if err != nil {
+			return err
+		}
+		file, err := fileFor(name)
 		if err != nil {
 			return err
 		}
-		f.Add(code)
+		file.Add(code)
+	}
+
+	if typ == DataSourceSchema || typ == DataSourceFromResource {
+		listNames := []string{}
+		for name := range opts.ListDataSources {
+			if _, found := objects[name]; found {
+				return fmt.Errorf("%s has been given multiple time", name)
+			}
+			listNames = append(listNames, name)
+		}
+		sort.Strings(listNames)
 
+		for _, name := range listNames {
+			code, err := renderListDataSourceSchema(converter, importPath, name, TypeOf(opts.ListDataSources[name]))
+			if err != nil {
+				return err
+			}
+			file, err := fileFor(name)
+			if err != nil {
+				return err
+			}
+			file.Add(code)
+		}
 	}
 
-	return f.Save(filepath.Join(path, "schema.go"))
+	return saveFiles(opts, path, string(typ)+" schema", []string{opts.FileNames.Schema}, generated...)
 }
 
-func renderObjectSchema(c *Converter, importPath, name string, typ reflect.Type, opts *GeneratorOptions) (*Statement, error) {
-	fields, _, err := iterateFields(name, opts.GetFieldInformation, typ)
+// DeprecatedType can be implemented by the types given to GenerateSchema to
+// deprecate the whole schema
+type DeprecatedType interface {
+	DeprecationMessage() string
+}
+
+// getTypeDocumentation returns the description and the deprecation message of
+// a schema
+func (c *Converter) getTypeDocumentation(typ GoType) (string, string, error) {
+	description, deprecation := "", ""
+	if c.docs != nil {
+		doc, err := c.docs.typeDoc(typ)
+		if err != nil {
+			return "", "", err
+		}
+		description, deprecation = parseDocComment(doc)
+	}
+
+	if t, ok := typ.(deprecatedType); ok {
+		message, err := t.deprecationMessage()
+		if err != nil {
+			return "", "", err
+		}
+		if message != "" {
+			deprecation = message
+		}
+	}
+	return description, deprecation, nil
+}
+
+func renderObjectSchema(c *Converter, importPath, name string, typ GoType, opts *GeneratorOptions) (*Statement, error) {
+	c.attributePaths = map[string]bool{}
+	c.references = nil
+
+	fields, err := c.GetFields(name, typ)
 	if err != nil {
 		return nil, err
 	}
@@ -92,22 +186,123 @@ func renderObjectSchema(c *Converter, importPath, name string, typ reflect.Type,
 		}
 	}
 
+	if err := c.checkReferences(); err != nil {
+		return nil, err
+	}
+
+	description, deprecation, err := c.getTypeDocumentation(typ)
+	if err != nil {
+		return nil, err
+	}
+	if deprecation != "" && importPath == ProviderMetaSchema.importPath() {
+		return nil, fmt.Errorf("%s: provider meta schemas cannot be deprecated", name)
+	}
+
 	return Func().Id(strcase.LowerCamelCase(name)+"Schema").Params().Qual(importPath, "Schema").BlockFunc(func(g *Group) {
-		g.Return().Qual(importPath, "Schema").Values(
-			Line().Id("MarkdownDescription").Op(":").Lit(""),
-			Line().Id("Attributes").Op(":").Map(String()).Qual(importPath, "Attribute").ValuesFunc(func(g *Group) {
+		g.Return().Qual(importPath, "Schema").ValuesFunc(func(g *Group) {
+			g.Line().Id("MarkdownDescription").Op(":").Lit(description)
+			if deprecation != "" {
+				g.Line().Id("DeprecationMessage").Op(":").Lit(deprecation)
+			}
+			g.Line().Id("Attributes").Op(":").Map(String()).Qual(importPath, "Attribute").ValuesFunc(func(g *Group) {
 				for _, code := range attributes {
 					g.Line().Add(code)
 				}
 				g.Line()
-			}),
-			Line().Id("Blocks").Op(":").Map(String()).Qual(importPath, "Block").ValuesFunc(func(g *Group) {
+			})
+			g.Line().Id("Blocks").Op(":").Map(String()).Qual(importPath, "Block").ValuesFunc(func(g *Group) {
 				for _, code := range blocks {
 					g.Line().Add(code)
 				}
 				g.Line()
-			}),
-			Line(),
-		)
+			})
+			g.Line()
+		})
+	}).Line(), nil
+}
+
+// renderListDataSourceSchema renders the schema of a list data source, the
+// elements are in a computed list named after the data source and the
+// filterable attributes are in the filter block. The description and the
+// deprecation message of the type of the elements are used for the schema.
+func renderListDataSourceSchema(c *Converter, importPath, name string, typ GoType) (*Statement, error) {
+	c.attributePaths = map[string]bool{}
+	c.references = nil
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if name == "filter" {
+		return nil, fmt.Errorf(`%s: a list data source cannot be named "filter"`, name)
+	}
+
+	description, deprecation, err := c.getTypeDocumentation(typ)
+	if err != nil {
+		return nil, err
+	}
+	items := &FieldInformation{
+		Name:        name,
+		Path:        name + "." + name,
+		Computed:    true,
+		Description: description,
+		goName:      "Items",
+		goType:      SliceOf(typ),
+	}
+	converter, err := c.Get(items.goType)
+	if err != nil {
+		return nil, err
+	}
+
+	// The elements are read from the API so all their attributes are
+	// computed
+	c.computed = true
+	attr, _, err := converter.GetSchema(c, items.Path, items)
+	c.computed = false
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := c.getFilterFields(name+".filter", typ)
+	if err != nil {
+		return nil, err
+	}
+	attributes := []Code{}
+	for _, field := range filters {
+		converter, err := c.Get(field.goType)
+		if err != nil {
+			return nil, err
+		}
+		filter, _, err := converter.GetSchema(c, field.Path, field)
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, Lit(field.Name).Op(":").Add(filter))
+	}
+
+	return Func().Id(strcase.LowerCamelCase(name)+"Schema").Params().Qual(importPath, "Schema").BlockFunc(func(g *Group) {
+		g.Return().Qual(importPath, "Schema").ValuesFunc(func(g *Group) {
+			g.Line().Id("MarkdownDescription").Op(":").Lit(description)
+			if deprecation != "" {
+				g.Line().Id("DeprecationMessage").Op(":").Lit(deprecation)
+			}
+			g.Line().Id("Attributes").Op(":").Map(String()).Qual(importPath, "Attribute").Values(
+				Line().Lit(name).Op(":").Add(attr),
+				Line(),
+			)
+			g.Line().Id("Blocks").Op(":").Map(String()).Qual(importPath, "Block").ValuesFunc(func(g *Group) {
+				if len(attributes) != 0 {
+					g.Line().Lit("filter").Op(":").Qual(importPath, "SingleNestedBlock").Values(
+						Line().Id("Attributes").Op(":").Map(String()).Qual(importPath, "Attribute").ValuesFunc(func(g *Group) {
+							for _, code := range attributes {
+								g.Line().Add(code)
+							}
+							g.Line()
+						}),
+						Line(),
+					)
+				}
+				g.Line()
+			})
+			g.Line()
+		})
 	}).Line(), nil
 }
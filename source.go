@@ -0,0 +1,337 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// SourceLoader loads the types from the source of their packages using
+// go/packages, contrary to TypeOf the packages do not have to be imported, and
+// so compiled, by the program calling the generator. The types it returns can
+// be given as the objects of GenerateModels and GenerateSchema.
+type SourceLoader struct {
+	// Dir is the directory the packages are loaded from, it must be part of
+	// the module of the packages. The current directory is used when it is
+	// empty.
+	Dir string
+
+	fset     *token.FileSet
+	packages map[string]*packages.Package
+	types    map[string]*sourceType
+	sizes    types.Sizes
+}
+
+func NewSourceLoader(dir string) *SourceLoader {
+	return &SourceLoader{
+		Dir:      dir,
+		fset:     token.NewFileSet(),
+		packages: map[string]*packages.Package{},
+		types:    map[string]*sourceType{},
+	}
+}
+
+// Load returns the type named name declared in the package pkgPath
+func (l *SourceLoader) Load(pkgPath, name string) (GoType, error) {
+	pkg, err := l.load(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := pkg.Types.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s not found in %s", name, pkgPath)
+	}
+	if named, ok := obj.Type().(*types.Named); ok && named.TypeParams().Len() != 0 {
+		return nil, fmt.Errorf("%s.%s: generic types are not supported", pkgPath, name)
+	}
+	return l.typeOf(obj.Type()), nil
+}
+
+func (l *SourceLoader) load(pkgPath string) (*packages.Package, error) {
+	if pkg, found := l.packages[pkgPath]; found {
+		return pkg, nil
+	}
+	// The package may already have been loaded as a dependency, reusing it
+	// keeps its types identical to the ones reached through its importers
+	for _, loaded := range l.packages {
+		if pkg := findImport(loaded, pkgPath, map[*packages.Package]bool{}); pkg != nil {
+			l.packages[pkgPath] = pkg
+			return pkg, nil
+		}
+	}
+
+	mode := packages.NeedName | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo
+	pkgs, err := packages.Load(&packages.Config{Mode: mode, Dir: l.Dir, Fset: l.fset}, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", pkgPath, err)
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("failed to load %s: expected one package, got %d", pkgPath, len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) != 0 {
+		return nil, fmt.Errorf("failed to load %s: %w", pkgPath, pkg.Errors[0])
+	}
+
+	if l.sizes == nil {
+		l.sizes = pkg.TypesSizes
+	}
+	l.packages[pkgPath] = pkg
+	return pkg, nil
+}
+
+// findImport looks for the package pkgPath in the dependencies of pkg
+func findImport(pkg *packages.Package, pkgPath string, seen map[*packages.Package]bool) *packages.Package {
+	if seen[pkg] {
+		return nil
+	}
+	seen[pkg] = true
+	if imported, found := pkg.Imports[pkgPath]; found {
+		return imported
+	}
+	for _, imported := range pkg.Imports {
+		if found := findImport(imported, pkgPath, seen); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// typeOf returns the canonical sourceType of typ so that the identical types
+// can be compared with ==. The types are indexed by their fully qualified name
+// since a package loaded twice, once directly and once as a dependency of
+// another one, declares distinct but equivalent types.
+func (l *SourceLoader) typeOf(typ types.Type) GoType {
+	typ = types.Unalias(typ)
+	if basic, ok := typ.(*types.Basic); ok {
+		// byte and rune are reported as uint8 and int32 like reflect does
+		typ = types.Typ[basic.Kind()]
+	}
+	key := types.TypeString(typ, nil)
+	if t, found := l.types[key]; found {
+		return t
+	}
+	t := &sourceType{loader: l, typ: typ}
+	l.types[key] = t
+	return t
+}
+
+// sourceType is the GoType backed by go/types
+type sourceType struct {
+	loader *SourceLoader
+	typ    types.Type
+}
+
+var _ GoType = &sourceType{}
+
+var basicKinds = map[types.BasicKind]reflect.Kind{
+	types.Bool:          reflect.Bool,
+	types.Int:           reflect.Int,
+	types.Int8:          reflect.Int8,
+	types.Int16:         reflect.Int16,
+	types.Int32:         reflect.Int32,
+	types.Int64:         reflect.Int64,
+	types.Uint:          reflect.Uint,
+	types.Uint8:         reflect.Uint8,
+	types.Uint16:        reflect.Uint16,
+	types.Uint32:        reflect.Uint32,
+	types.Uint64:        reflect.Uint64,
+	types.Uintptr:       reflect.Uintptr,
+	types.Float32:       reflect.Float32,
+	types.Float64:       reflect.Float64,
+	types.Complex64:     reflect.Complex64,
+	types.Complex128:    reflect.Complex128,
+	types.String:        reflect.String,
+	types.UnsafePointer: reflect.UnsafePointer,
+}
+
+func (t *sourceType) Kind() reflect.Kind {
+	switch u := t.typ.Underlying().(type) {
+	case *types.Basic:
+		return basicKinds[u.Kind()]
+	case *types.Pointer:
+		return reflect.Pointer
+	case *types.Slice:
+		return reflect.Slice
+	case *types.Array:
+		return reflect.Array
+	case *types.Map:
+		return reflect.Map
+	case *types.Chan:
+		return reflect.Chan
+	case *types.Struct:
+		return reflect.Struct
+	case *types.Interface:
+		return reflect.Interface
+	case *types.Signature:
+		return reflect.Func
+	}
+	return reflect.Invalid
+}
+
+func (t *sourceType) Name() string {
+	switch typ := t.typ.(type) {
+	case *types.Named:
+		return typ.Obj().Name()
+	case *types.Basic:
+		return typ.Name()
+	}
+	return ""
+}
+
+func (t *sourceType) PkgPath() string {
+	if named, ok := t.typ.(*types.Named); ok && named.Obj().Pkg() != nil {
+		return named.Obj().Pkg().Path()
+	}
+	return ""
+}
+
+func (t *sourceType) String() string {
+	return types.TypeString(t.typ, func(pkg *types.Package) string {
+		return pkg.Name()
+	})
+}
+
+func (t *sourceType) Bits() int {
+	return int(t.loader.sizes.Sizeof(t.typ)) * 8
+}
+
+func (t *sourceType) Elem() GoType {
+	switch u := t.typ.Underlying().(type) {
+	case *types.Pointer:
+		return t.loader.typeOf(u.Elem())
+	case *types.Slice:
+		return t.loader.typeOf(u.Elem())
+	case *types.Array:
+		return t.loader.typeOf(u.Elem())
+	case *types.Map:
+		return t.loader.typeOf(u.Elem())
+	case *types.Chan:
+		return t.loader.typeOf(u.Elem())
+	}
+	return newInvalidType("Elem of invalid type %s", t)
+}
+
+func (t *sourceType) Key() GoType {
+	if u, ok := t.typ.Underlying().(*types.Map); ok {
+		return t.loader.typeOf(u.Key())
+	}
+	return newInvalidType("Key of non-map type %s", t)
+}
+
+func (t *sourceType) NumMethod() int {
+	if u, ok := t.typ.Underlying().(*types.Interface); ok {
+		return u.NumMethods()
+	}
+	n := 0
+	methods := types.NewMethodSet(t.typ)
+	for i := 0; i < methods.Len(); i++ {
+		if methods.At(i).Obj().Exported() {
+			n++
+		}
+	}
+	return n
+}
+
+func (t *sourceType) NumField() int {
+	if s, ok := t.typ.Underlying().(*types.Struct); ok {
+		return s.NumFields()
+	}
+	return 0
+}
+
+func (t *sourceType) Field(i int) StructField {
+	s, ok := t.typ.Underlying().(*types.Struct)
+	if !ok || i < 0 || i >= s.NumFields() {
+		return StructField{Type: newInvalidType("field %d of type %s", i, t)}
+	}
+	field := s.Field(i)
+	pkgPath := ""
+	if !field.Exported() {
+		pkgPath = field.Pkg().Path()
+	}
+	return StructField{
+		Name:      field.Name(),
+		PkgPath:   pkgPath,
+		Type:      t.loader.typeOf(field.Type()),
+		Tag:       reflect.StructTag(s.Tag(i)),
+		Anonymous: field.Embedded(),
+	}
+}
+
+// invalidType is returned by the methods of sourceType given an invalid
+// type, reflect panics in this case. Its kind is reflect.Invalid and
+// Converter.Get returns its error, so the generators fail with it.
+type invalidType struct {
+	err error
+}
+
+var _ GoType = &invalidType{}
+
+func newInvalidType(format string, args ...interface{}) *invalidType {
+	return &invalidType{err: fmt.Errorf(format, args...)}
+}
+
+func (t *invalidType) Kind() reflect.Kind    { return reflect.Invalid }
+func (t *invalidType) Name() string          { return "" }
+func (t *invalidType) PkgPath() string       { return "" }
+func (t *invalidType) String() string        { return "invalid type: " + t.err.Error() }
+func (t *invalidType) Bits() int             { return 0 }
+func (t *invalidType) Elem() GoType          { return t }
+func (t *invalidType) Key() GoType           { return t }
+func (t *invalidType) NumMethod() int        { return 0 }
+func (t *invalidType) NumField() int         { return 0 }
+func (t *invalidType) Field(int) StructField { return StructField{Type: t} }
+func (t *invalidType) sliceOf() GoType       { return t }
+
+func (t *invalidType) deprecationMessage() (string, error) {
+	return "", t.err
+}
+
+func (t *sourceType) sliceOf() GoType {
+	return t.loader.typeOf(types.NewSlice(t.typ))
+}
+
+// deprecationMessage looks for the DeprecationMessage method of the type, the
+// method cannot be called so it must return a string literal
+func (t *sourceType) deprecationMessage() (string, error) {
+	typ := t.typ
+	if _, ok := typ.(*types.Pointer); !ok {
+		typ = types.NewPointer(typ)
+	}
+	selection := types.NewMethodSet(typ).Lookup(nil, "DeprecationMessage")
+	if selection == nil {
+		return "", nil
+	}
+	method := selection.Obj().(*types.Func)
+	signature := method.Type().(*types.Signature)
+	if signature.Params().Len() != 0 || signature.Results().Len() != 1 || !types.Identical(signature.Results().At(0).Type(), types.Typ[types.String]) {
+		return "", nil
+	}
+
+	pkg, err := t.loader.load(method.Pkg().Path())
+	if err != nil {
+		return "", err
+	}
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Name.Pos() != method.Pos() {
+				continue
+			}
+			if fn.Body != nil && len(fn.Body.List) == 1 {
+				if ret, ok := fn.Body.List[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+					if lit, ok := ret.Results[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						return strconv.Unquote(lit.Value)
+					}
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("%s: DeprecationMessage() must return a string literal when the types are loaded from their source", t)
+}
@@ -3,6 +3,8 @@ package generator
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/dave/jennifer/jen"
@@ -25,14 +27,14 @@ const (
 	timeType          stringValueType = iota
 )
 
-func getStringType(typ reflect.Type) stringValueType {
-	if typ.String() == "[]uint8" {
+func getStringType(typ GoType) stringValueType {
+	if typ.Kind() == reflect.Slice && typ.Name() == "" && typ.Elem().String() == "uint8" {
 		return byteType
 	}
 	if typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
 	}
-	if typ == reflect.TypeOf(time.Duration(0)) || typ == reflect.TypeOf(time.Time{}) {
+	if isNamedType(typ, "time", "Duration") || isNamedType(typ, "time", "Time") {
 		return timeType
 	}
 	if typ.Kind() == reflect.String {
@@ -41,15 +43,15 @@ func getStringType(typ reflect.Type) stringValueType {
 	return invalidStringType
 }
 
-func (c *StringConverter) Check(typ reflect.Type) (bool, error) {
+func (c *StringConverter) Check(typ GoType) (bool, error) {
 	return getStringType(typ) != invalidStringType, nil
 }
 
-func (c *StringConverter) GetFrameworkType(_ *Converter, typ reflect.Type) (*jen.Statement, error) {
+func (c *StringConverter) GetFrameworkType(_ *Converter, typ GoType) (*jen.Statement, error) {
 	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "String"), nil
 }
 
-func (c *StringConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *StringConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	switch getStringType(typ) {
 	case byteType:
 		return decodeBytes(converters, path, src, target, typ)
@@ -61,7 +63,7 @@ func (c *StringConverter) Decode(converters *Converter, field *FieldInformation,
 	return nil, fmt.Errorf("invalid string type")
 }
 
-func decodeString(converters *Converter, path, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func decodeString(converters *Converter, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	method := "ValueString"
 	if typ.Kind() == reflect.Pointer {
 		method = "ValueStringPointer"
@@ -69,7 +71,7 @@ func decodeString(converters *Converter, path, src, target *jen.Statement, typ r
 	}
 
 	value := src.Clone().Dot(method).Call()
-	if typ != reflect.TypeOf("") {
+	if !isNamedType(typ, "", "string") {
 		// Taking care of the aliases
 		value = jen.Qual(typ.PkgPath(), typ.Name()).Call(value)
 	}
@@ -79,13 +81,13 @@ func decodeString(converters *Converter, path, src, target *jen.Statement, typ r
 	), nil
 }
 
-func decodeBytes(converters *Converter, path, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func decodeBytes(converters *Converter, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	return jen.If().Op("!").Add(src.Clone()).Dot("IsNull").Call().Block(
 		target.Op("=").Index().Byte().Call(src.Clone().Dot("ValueString").Call()),
 	), nil
 }
 
-func decodeTime(converters *Converter, path, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func decodeTime(converters *Converter, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	op := jen.Empty()
 	if typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
@@ -95,7 +97,7 @@ func decodeTime(converters *Converter, path, src, target *jen.Statement, typ ref
 	ident := "dur"
 	parseFunc := jen.Qual("time", "ParseDuration").Call(src.Clone().Dot("ValueString").Call())
 	errMessage := "failed to parse duration"
-	if typ == reflect.TypeOf(time.Time{}) {
+	if isNamedType(typ, "time", "Time") {
 		ident = "t"
 		parseFunc = jen.Qual("time", "Parse").Call(jen.Qual("time", "RFC3339"), src.Clone().Dot("ValueString").Call())
 		errMessage = "failed to parse time string"
@@ -116,7 +118,7 @@ func decodeTime(converters *Converter, path, src, target *jen.Statement, typ ref
 	), nil
 }
 
-func (c *StringConverter) Encode(converters *Converter, field *FieldInformation, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *StringConverter) Encode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	switch getStringType(typ) {
 	case byteType:
 		return encodeBytes(converters, src, target, typ)
@@ -128,11 +130,11 @@ func (c *StringConverter) Encode(converters *Converter, field *FieldInformation,
 	return nil, fmt.Errorf("invalid string type")
 }
 
-func encodeBytes(converters *Converter, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func encodeBytes(converters *Converter, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	return encodeString(converters, src, target, typ)
 }
 
-func encodeString(converters *Converter, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func encodeString(converters *Converter, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	ptr := false
 	value := src.Clone()
 	if typ.Kind() == reflect.Pointer {
@@ -142,7 +144,7 @@ func encodeString(converters *Converter, src, target *jen.Statement, typ reflect
 	}
 
 	// If it is a raw string we use the standard functions to do the conversion
-	if typ == reflect.TypeOf("") {
+	if isNamedType(typ, "", "string") {
 		method := "StringValue"
 		if ptr {
 			method = "StringPointerValue"
@@ -162,7 +164,7 @@ func encodeString(converters *Converter, src, target *jen.Statement, typ reflect
 	return code, nil
 }
 
-func encodeTime(converters *Converter, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func encodeTime(converters *Converter, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	ptr := false
 	if typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
@@ -170,7 +172,7 @@ func encodeTime(converters *Converter, src, target *jen.Statement, typ reflect.T
 	}
 
 	var code *jen.Statement
-	if typ == reflect.TypeOf(time.Time{}) {
+	if isNamedType(typ, "time", "Time") {
 		code = target.Op("=").Qual("github.com/hashicorp/terraform-plugin-framework/types", "StringValue").Call(src.Clone().Dot("Format").Call(jen.Qual("time", "RFC3339")))
 	} else {
 		code = target.Op("=").Qual("github.com/hashicorp/terraform-plugin-framework/types", "StringValue").Call(src.Clone().Dot("String").Call())
@@ -190,3 +192,86 @@ func (c *StringConverter) GetSchema(converters *Converter, path string, info *Fi
 func (c *StringConverter) GetType() *jen.Statement {
 	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "StringType")
 }
+
+func (c *StringConverter) GetAttrType(_ *Converter, _ *FieldInformation, _ GoType) (*jen.Statement, error) {
+	return c.GetType(), nil
+}
+
+func (c *StringConverter) GetValue(_ *Converter, _ *FieldInformation, typ GoType, value interface{}) (*jen.Statement, error) {
+	if value == nil {
+		return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "StringNull").Call(), nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a string, got %T", value)
+	}
+	if err := checkString(typ, s); err != nil {
+		return nil, err
+	}
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "StringValue").Call(jen.Lit(s)), nil
+}
+
+func (c *StringConverter) GetDefault(_ *Converter, info *FieldInformation, literal string) (*jen.Statement, error) {
+	if err := checkString(info.goType, literal); err != nil {
+		return nil, err
+	}
+	return jen.Qual(resourceSchemaImportPath+"stringdefault", "StaticString").Call(jen.Lit(literal)), nil
+}
+
+// checkString makes sure that the times and durations can be parsed
+func checkString(typ GoType, value string) error {
+	if getStringType(typ) != timeType {
+		return nil
+	}
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	var err error
+	if isNamedType(typ, "time", "Time") {
+		_, err = time.Parse(time.RFC3339, value)
+	} else {
+		_, err = time.ParseDuration(value)
+	}
+	return err
+}
+
+func (c *StringConverter) GetAttributeKind(_ *FieldInformation) string {
+	return "String"
+}
+
+func (c *StringConverter) GetValidators(_ *Converter, info *FieldInformation, constraints []Constraint) ([]jen.Code, error) {
+	importPath := validatorPackage("String")
+
+	codes := []jen.Code{}
+	for _, constraint := range constraints {
+		switch constraint.Name {
+		case "oneof":
+			values := []jen.Code{}
+			for _, v := range strings.Split(constraint.Value, "|") {
+				if err := checkString(info.goType, v); err != nil {
+					return nil, fmt.Errorf("invalid oneof value %q: %w", v, err)
+				}
+				values = append(values, jen.Lit(v))
+			}
+			codes = append(codes, jen.Qual(importPath, "OneOf").Call(values...))
+		case "regex":
+			if _, err := regexp.Compile(constraint.Value); err != nil {
+				return nil, fmt.Errorf("invalid regex: %w", err)
+			}
+			codes = append(codes, jen.Qual(importPath, "RegexMatches").Call(
+				jen.Qual("regexp", "MustCompile").Call(jen.Lit(constraint.Value)),
+				jen.Lit(fmt.Sprintf("must match %s", constraint.Value)),
+			))
+		case "length":
+			min, max, err := parseRange(constraint.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid length value %q: %w", constraint.Value, err)
+			}
+			codes = append(codes, boundValidators(importPath, "LengthAtLeast", "LengthAtMost", "LengthBetween", min, max)...)
+		default:
+			return nil, unsupportedConstraint(constraint, "String")
+		}
+	}
+
+	return codes, nil
+}
@@ -12,14 +12,14 @@ type StructConverter struct{}
 
 var _ AttributeConverter = &StructConverter{}
 
-func (c *StructConverter) Check(typ reflect.Type) (bool, error) {
+func (c *StructConverter) Check(typ GoType) (bool, error) {
 	if typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
 	}
 	return typ.Kind() == reflect.Struct, nil
 }
 
-func (c *StructConverter) GetFrameworkType(converters *Converter, typ reflect.Type) (*jen.Statement, error) {
+func (c *StructConverter) GetFrameworkType(converters *Converter, typ GoType) (*jen.Statement, error) {
 	if typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
 	}
@@ -30,7 +30,7 @@ func (c *StructConverter) GetFrameworkType(converters *Converter, typ reflect.Ty
 	return jen.Op("*").Id(name), nil
 }
 
-func (c *StructConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *StructConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	ref := jen.Op("*").Id("item")
 	if typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
@@ -53,7 +53,7 @@ func (c *StructConverter) Decode(converters *Converter, field *FieldInformation,
 	), nil
 }
 
-func (c *StructConverter) Encode(converters *Converter, field *FieldInformation, src, target *jen.Statement, typ reflect.Type) (*jen.Statement, error) {
+func (c *StructConverter) Encode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
 	ptr := false
 	if typ.Kind() == reflect.Pointer {
 		typ = typ.Elem()
@@ -71,7 +71,7 @@ func (c *StructConverter) Encode(converters *Converter, field *FieldInformation,
 	}
 
 	return jen.Block(
-		jen.List(jen.Id("data"), jen.Id("d")).Op(":=").Id(encodingFuncName).Call(op.Add(src)),
+		jen.List(jen.Id("data"), jen.Id("d")).Op(":=").Id(encodingFuncName).Call(path, op.Add(src)),
 		jen.Id("diags").Dot("Append").Call(jen.Id("d").Op("...")),
 		jen.If(jen.Id("diags").Dot("HasError").Call()).Block(
 			jen.Return(jen.List(jen.Nil(), jen.Id("diags"))),
@@ -142,12 +142,18 @@ func (c *StructConverter) GetSchema(converters *Converter, path string, info *Fi
 		if info.Description != "" {
 			g.Line().Id("MarkdownDescription").Op(":").Lit(info.Description)
 		}
+		if info.DeprecationMessage != "" {
+			g.Line().Id("DeprecationMessage").Op(":").Lit(info.DeprecationMessage)
+		}
 		if info.Default != nil {
 			g.Line().Id("Default").Op(":").Add(info.Default)
 		}
 		if info.Validators != nil {
 			g.Line().Id("Validators").Op(":").Add(info.Validators)
 		}
+		if info.PlanModifiers != nil {
+			g.Line().Id("PlanModifiers").Op(":").Add(info.PlanModifiers)
+		}
 		for _, code := range codes {
 			g.Line().Add(code)
 		}
@@ -162,3 +168,100 @@ func (c *StructConverter) GetSchema(converters *Converter, path string, info *Fi
 	attrType.Qual(converters.SchemaImportPath(), "SingleNestedAttribute")
 	return result, nil, nil
 }
+
+func (c *StructConverter) GetAttrType(converters *Converter, info *FieldInformation, typ GoType) (*jen.Statement, error) {
+	attrTypes, err := c.getAttrTypes(converters, info, typ)
+	if err != nil {
+		return nil, err
+	}
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "ObjectType").Values(
+		jen.Id("AttrTypes").Op(":").Add(attrTypes),
+	), nil
+}
+
+func (c *StructConverter) getAttrTypes(converters *Converter, info *FieldInformation, typ GoType) (*jen.Statement, error) {
+	fields, err := converters.GetFields(info.Path, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	attrTypes := []jen.Code{}
+	for _, field := range fields {
+		attrType, err := converters.GetAttrType(field, field.goType)
+		if err != nil {
+			return nil, err
+		}
+		attrTypes = append(attrTypes, jen.Line().Lit(field.Name).Op(":").Add(attrType))
+	}
+	attrTypes = append(attrTypes, jen.Line())
+
+	return jen.Map(jen.String()).Qual("github.com/hashicorp/terraform-plugin-framework/attr", "Type").Values(attrTypes...), nil
+}
+
+func (c *StructConverter) GetValue(converters *Converter, info *FieldInformation, typ GoType, value interface{}) (*jen.Statement, error) {
+	attrTypes, err := c.getAttrTypes(converters, info, typ)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "ObjectNull").Call(attrTypes), nil
+	}
+
+	items, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON object, got %T", value)
+	}
+
+	fields, err := converters.GetFields(info.Path, typ)
+	if err != nil {
+		return nil, err
+	}
+	known := map[string]struct{}{}
+	values := []jen.Code{}
+	for _, field := range fields {
+		known[field.Name] = struct{}{}
+		v, err := converters.GetValue(field, field.goType, items[field.Name])
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, jen.Line().Lit(field.Name).Op(":").Add(v))
+	}
+	values = append(values, jen.Line())
+
+	for k := range items {
+		if _, found := known[k]; !found {
+			return nil, fmt.Errorf("unknown attribute %q", k)
+		}
+	}
+
+	return jen.Qual("github.com/hashicorp/terraform-plugin-framework/types", "ObjectValueMust").Call(
+		attrTypes,
+		jen.Map(jen.String()).Qual("github.com/hashicorp/terraform-plugin-framework/attr", "Value").Values(values...),
+	), nil
+}
+
+func (c *StructConverter) GetDefault(converters *Converter, info *FieldInformation, literal string) (*jen.Statement, error) {
+	value, err := decodeLiteral(literal)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, fmt.Errorf("expected a JSON object, got null")
+	}
+	code, err := c.GetValue(converters, info, info.goType, value)
+	if err != nil {
+		return nil, err
+	}
+	return jen.Qual(resourceSchemaImportPath+"objectdefault", "StaticValue").Call(code), nil
+}
+
+func (c *StructConverter) GetAttributeKind(_ *FieldInformation) string {
+	return "Object"
+}
+
+func (c *StructConverter) GetValidators(_ *Converter, _ *FieldInformation, constraints []Constraint) ([]jen.Code, error) {
+	if len(constraints) != 0 {
+		return nil, unsupportedConstraint(constraints[0], "Object")
+	}
+	return nil, nil
+}
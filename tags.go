@@ -1,11 +1,14 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
+	"github.com/stoewer/go-strcase"
 )
 
 type FieldInformation struct {
@@ -16,29 +19,169 @@ type FieldInformation struct {
 	Computed    bool
 	Sensitive   bool
 	Description string
-	Block       bool
-	Default     *jen.Statement
-	Validators  *jen.Statement
+	// DeprecationMessage marks the attribute as deprecated, the framework
+	// adds a warning when it is set in the configuration
+	DeprecationMessage string
+	Block              bool
+	Set                bool
+	// Number renders the uint64 fields as a types.Number instead of a
+	// types.Int64 that cannot hold the values above math.MaxInt64
+	Number     bool
+	Default    *jen.Statement
+	Validators *jen.Statement
+	// PlanModifiers are only rendered in resource schemas
+	PlanModifiers *jen.Statement
+
+	// DefaultLiteral is the default value as given in the tag, it is
+	// converted to Default by the attribute converter when rendering a
+	// resource schema
+	DefaultLiteral string
+	// HasDefault is set when the default modifier is given, DefaultLiteral
+	// can be empty for the empty string
+	HasDefault bool
+	// Constraints are the validation modifiers given in the tag, they are
+	// converted to Validators by the attribute converter
+	Constraints []Constraint
+	// PlanModifierNames are the plan modifiers given in the tag, they are
+	// converted to PlanModifiers when rendering a resource schema
+	PlanModifierNames []string
+
+	// Lookup marks the attributes used to find the object when a data source
+	// schema is derived from a resource
+	Lookup bool
+	// Filterable adds the attribute to the filter block of the list data
+	// sources
+	Filterable bool
 
 	Promoted bool
 	Parent   *FieldInformation
 
 	// Go data
 	goName   string
-	goType   reflect.Type
+	goType   GoType
 	accessor *jen.Statement
+	owner    GoType
+}
+
+// modifierValues lists the known modifiers and whether they expect a value
+var modifierValues = map[string]bool{
+	"sensitive":   false,
+	"promoted":    false,
+	"optional":    false,
+	"required":    false,
+	"computed":    false,
+	"block":       false,
+	"lookup":      false,
+	"filterable":  false,
+	"set":         false,
+	"number":      false,
+	"default":     true,
+	"description": true,
+	"deprecated":  true,
+	"oneof":       true,
+	"min":         true,
+	"max":         true,
+	"regex":       true,
+	"length":      true,
+	"min_items":   true,
+	"max_items":   true,
+
+	"conflicts_with":  true,
+	"exactly_one_of":  true,
+	"at_least_one_of": true,
+	"also_requires":   true,
+
+	"force_new":                      false,
+	"use_state_for_unknown":          false,
+	"requires_replace_if_configured": false,
+}
+
+// FieldInformationGetter returns the information of a struct field, the schema
+// type is the one being rendered, it is empty when the models are rendered.
+type FieldInformationGetter func(SchemaType, string, GoType, StructField) (*FieldInformation, error)
+
+// SkipField can be returned by a FieldInformationGetter to exclude a field from
+// the schema and models, contrary to returning nil it also prevents the
+// following getters of FallbackFieldInformationGetter from being called.
+var SkipField = errors.New("skip this field")
+
+// FallbackFieldInformationGetter returns a FieldInformationGetter that calls
+// each getter in turn until one of them returns information for the field. It
+// can be used to layer GetFieldInformationFromJSONTag under
+// GetFieldInformationFromTerraformTag so that the terraform tag is only
+// needed to override the defaults.
+func FallbackFieldInformationGetter(getters ...FieldInformationGetter) FieldInformationGetter {
+	return func(schemaType SchemaType, path string, typ GoType, field StructField) (*FieldInformation, error) {
+		for _, getter := range getters {
+			info, err := getter(schemaType, path, typ, field)
+			if err != nil || info != nil {
+				return info, err
+			}
+		}
+		return nil, nil
+	}
 }
 
-type FieldInformationGetter func(string, reflect.Type, reflect.StructField) (*FieldInformation, error)
+// GetFieldInformationFromJSONTag uses the json tags of the fields, the names
+// are converted to snake case, the fields using omitempty are optional while
+// the others are required and the anonymous embedded structs are promoted.
+func GetFieldInformationFromJSONTag(_ SchemaType, _ string, _ GoType, field StructField) (*FieldInformation, error) {
+	if !field.IsExported() && !field.Anonymous {
+		return nil, nil
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return nil, SkipField
+	}
+	name, options, _ := strings.Cut(tag, ",")
+
+	result := &FieldInformation{
+		Name:     strcase.SnakeCase(name),
+		goName:   field.Name,
+		goType:   field.Type,
+		accessor: jen.Dot(field.Name),
+	}
+
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+		result.Promoted = true
+		return result, nil
+	}
+	if !field.IsExported() {
+		return nil, nil
+	}
+
+	if name == "" {
+		result.Name = strcase.SnakeCase(field.Name)
+	}
+	result.Required = true
+	for _, option := range strings.Split(options, ",") {
+		if option == "omitempty" {
+			result.Optional = true
+			result.Required = false
+		}
+	}
+
+	return result, nil
+}
 
-func GetFieldInformationFromTerraformTag(_ string, typ reflect.Type, field reflect.StructField) (*FieldInformation, error) {
+// GetFieldInformationFromTerraformTag uses the terraform tags of the fields.
+// The optional, required and computed modifiers can be prefixed by a schema
+// type, e.g. "datasource:computed", to only apply to this schema type, they
+// then replace the modes that are not prefixed.
+func GetFieldInformationFromTerraformTag(schemaType SchemaType, _ string, typ GoType, field StructField) (*FieldInformation, error) {
 	tag, ok := field.Tag.Lookup("terraform")
 	if !ok {
 		return nil, nil
 	}
 
+	var err error
 	modifiers := map[string]struct{}{}
-	values := strings.Split(tag, ",")
+	values := splitTag(tag)
 	name, values := values[0], values[1:]
 
 	result := &FieldInformation{
@@ -48,8 +191,51 @@ func GetFieldInformationFromTerraformTag(_ string, typ reflect.Type, field refle
 		accessor: jen.Dot(field.Name),
 	}
 
+	// scoped holds the modes given for the schema type being rendered
+	var scoped *FieldInformation
+
 	for _, v := range values {
-		switch v {
+		key, value, hasValue := strings.Cut(v, "=")
+		if scope, mode, found := strings.Cut(key, ":"); found {
+			if !isSchemaType(scope) {
+				return nil, fmt.Errorf("unknown schema type %q in modifier %q", scope, v)
+			}
+			if mode != "optional" && mode != "required" && mode != "computed" {
+				return nil, fmt.Errorf("only the optional, required and computed modifiers can be given for a schema type, got %q", v)
+			}
+			if hasValue {
+				return nil, fmt.Errorf("the %s modifier does not take a value", mode)
+			}
+			if _, found := modifiers[key]; found {
+				return nil, fmt.Errorf("%s modifier given multiple time", key)
+			}
+			modifiers[key] = struct{}{}
+
+			if SchemaType(scope) != schemaType {
+				continue
+			}
+			if scoped == nil {
+				scoped = &FieldInformation{}
+			}
+			switch mode {
+			case "optional":
+				scoped.Optional = true
+			case "required":
+				scoped.Required = true
+			case "computed":
+				scoped.Computed = true
+			}
+			continue
+		}
+
+		if takesValue, known := modifierValues[key]; known && takesValue != hasValue {
+			if takesValue {
+				return nil, fmt.Errorf("the %s modifier expects a value", key)
+			}
+			return nil, fmt.Errorf("the %s modifier does not take a value", key)
+		}
+
+		switch key {
 		case "sensitive":
 			if _, found := modifiers["sensitive"]; found {
 				return nil, fmt.Errorf("sensitive modifier given multiple time")
@@ -90,14 +276,162 @@ func GetFieldInformationFromTerraformTag(_ string, typ reflect.Type, field refle
 			}
 			modifiers["block"] = struct{}{}
 			result.Block = true
+		case "lookup":
+			if _, found := modifiers["lookup"]; found {
+				return nil, fmt.Errorf("lookup modifier given multiple time")
+			}
+			modifiers["lookup"] = struct{}{}
+			result.Lookup = true
+		case "filterable":
+			if _, found := modifiers["filterable"]; found {
+				return nil, fmt.Errorf("filterable modifier given multiple time")
+			}
+			modifiers["filterable"] = struct{}{}
+			result.Filterable = true
+		case "set":
+			if _, found := modifiers["set"]; found {
+				return nil, fmt.Errorf("set modifier given multiple time")
+			}
+			modifiers["set"] = struct{}{}
+			result.Set = true
+		case "number":
+			if _, found := modifiers["number"]; found {
+				return nil, fmt.Errorf("number modifier given multiple time")
+			}
+			modifiers["number"] = struct{}{}
+			result.Number = true
+		case "default":
+			if _, found := modifiers["default"]; found {
+				return nil, fmt.Errorf("default modifier given multiple time")
+			}
+			modifiers["default"] = struct{}{}
+			result.DefaultLiteral = value
+			result.HasDefault = true
+		case "description":
+			if _, found := modifiers["description"]; found {
+				return nil, fmt.Errorf("description modifier given multiple time")
+			}
+			modifiers["description"] = struct{}{}
+			result.Description, err = unquoteTagValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid description %s: %w", value, err)
+			}
+		case "deprecated":
+			if _, found := modifiers["deprecated"]; found {
+				return nil, fmt.Errorf("deprecated modifier given multiple time")
+			}
+			modifiers["deprecated"] = struct{}{}
+			result.DeprecationMessage, err = unquoteTagValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid deprecation message %s: %w", value, err)
+			}
+		case "oneof", "min", "max", "regex", "length", "min_items", "max_items",
+			"conflicts_with", "exactly_one_of", "at_least_one_of", "also_requires":
+			if _, found := modifiers[key]; found {
+				return nil, fmt.Errorf("%s modifier given multiple time", key)
+			}
+			modifiers[key] = struct{}{}
+			result.Constraints = append(result.Constraints, Constraint{Name: key, Value: value})
+		case "force_new", "use_state_for_unknown", "requires_replace_if_configured":
+			if _, found := modifiers[key]; found {
+				return nil, fmt.Errorf("%s modifier given multiple time", key)
+			}
+			modifiers[key] = struct{}{}
+			result.PlanModifierNames = append(result.PlanModifierNames, key)
 		default:
 			return nil, fmt.Errorf("unknown modifier %q", v)
 		}
 	}
 
+	if result.Name == "-" && !result.Promoted {
+		return nil, SkipField
+	}
+
+	if scoped != nil {
+		result.Optional = scoped.Optional
+		result.Required = scoped.Required
+		result.Computed = scoped.Computed
+	}
+
+	if result.Set && !isCollection(field.Type) {
+		return nil, fmt.Errorf("the set modifier can only be used on slices, got %s", field.Type.String())
+	}
+
+	if result.HasDefault && result.Required {
+		return nil, fmt.Errorf("the default modifier cannot be used on a required attribute")
+	}
+
 	if !result.Required && !result.Computed {
 		result.Optional = true
 	}
 
 	return result, nil
 }
+
+// isNestedCollection returns whether typ is rendered as a list, a set or a map
+// whose elements add a step in the attribute paths
+func isNestedCollection(typ GoType) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Slice:
+		return typ.Elem().Kind() != reflect.Uint8
+	case reflect.Map:
+		return true
+	}
+	return false
+}
+
+// isCollection returns whether typ is a slice, or a map of slices, that can be
+// rendered as a set
+func isCollection(typ GoType) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8
+}
+
+// unquoteTagValue returns the value of a modifier, it can be quoted to contain
+// commas
+func unquoteTagValue(value string) (string, error) {
+	if strings.HasPrefix(value, `"`) {
+		return strconv.Unquote(value)
+	}
+	return value, nil
+}
+
+// splitTag splits the tag on commas, ignoring those that are in a quoted
+// string or between brackets so that modifiers can take JSON values or regular
+// expressions
+func splitTag(tag string) []string {
+	res := []string{}
+	depth := 0
+	quoted := false
+	start := 0
+	for i := 0; i < len(tag); i++ {
+		switch tag[i] {
+		case '\\':
+			i++
+		case '"':
+			quoted = !quoted
+		case '[', '{', '(':
+			if !quoted {
+				depth++
+			}
+		case ']', '}', ')':
+			if !quoted && depth > 0 {
+				depth--
+			}
+		case ',':
+			if !quoted && depth == 0 {
+				res = append(res, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(res, tag[start:])
+}
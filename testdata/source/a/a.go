@@ -0,0 +1,8 @@
+package a
+
+import "github.com/Lenstra/terraform-plugin-generator/testdata/source/b"
+
+type Order struct {
+	ID       int64       `terraform:"id"`
+	Customer *b.Customer `terraform:"customer"`
+}
@@ -0,0 +1,15 @@
+package b
+
+type Customer struct {
+	ID   int64  `terraform:"id"`
+	Name string `terraform:"name"`
+}
+
+// DeprecationMessage deprecates the customer schema.
+func (Customer) DeprecationMessage() string {
+	return "Customers are now managed by the order resource."
+}
+
+type Page[T any] struct {
+	Items []T `terraform:"items"`
+}
@@ -0,0 +1,454 @@
+/*
+Code generated by github-terraform-generator; DO NOT EDIT.
+Any modifications will be overwritten
+*/
+// Generator: models
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+	structs "github.com/Lenstra/terraform-plugin-generator/tests/structs"
+	diag "github.com/hashicorp/terraform-plugin-framework/diag"
+	path "github.com/hashicorp/terraform-plugin-framework/path"
+	"math"
+)
+
+type Getter interface {
+	Get(context.Context, interface{}) diag.Diagnostics
+}
+
+func Decode[Target **structs.Limits | **ListDataSource[structs.Coffee] | **ListDataSource[structs.Customer] | **ListDataSource[structs.Ingredient]](ctx context.Context, getter Getter, obj Target) diag.Diagnostics {
+	switch o := any(obj).(type) {
+	case **structs.Limits:
+		return DecodeLimits(ctx, getter, o)
+	case **ListDataSource[structs.Coffee]:
+		return DecodeCoffees(ctx, getter, o)
+	case **ListDataSource[structs.Customer]:
+		return DecodeCustomers(ctx, getter, o)
+	case **ListDataSource[structs.Ingredient]:
+		return DecodeIngredients(ctx, getter, o)
+	default:
+		var diags diag.Diagnostics
+		diags.AddError("unsupported object type", fmt.Sprintf("%T is not supported in %s.Set(). Please report this issue to the provider developers.", obj, "datasources"))
+		return diags
+	}
+}
+
+func DecodeLimits(ctx context.Context, getter Getter, limits **structs.Limits) diag.Diagnostics {
+	var data *Limits
+	diags := getter.Get(ctx, &data)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(decodeLimits(path.Empty(), data, limits)...)
+	return diags
+}
+
+func DecodeCoffees(ctx context.Context, getter Getter, coffees **ListDataSource[structs.Coffee]) diag.Diagnostics {
+	var data *Coffees
+	diags := getter.Get(ctx, &data)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(decodeCoffees(path.Empty(), data, coffees)...)
+	return diags
+}
+
+func decodeCoffees(path path.Path, data *Coffees, coffees **ListDataSource[structs.Coffee]) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &ListDataSource[structs.Coffee]{}
+	if *coffees == nil {
+		*coffees = target
+	} else {
+		target = *coffees
+	}
+
+	if data.Items != nil {
+		target.Items = make([]structs.Coffee, len(data.Items))
+		for i, data := range data.Items {
+			if data != nil {
+				var item *structs.Coffee
+				diags.Append(decodeCoffee(path.AtName("coffees").AtListIndex(i), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Items[i] = *item
+			}
+		}
+	}
+
+	diags.Append(decodeCoffeesFilter(path.AtName("filter"), data.Filter, &target.Filter)...)
+	return diags
+}
+
+func decodeCoffeesFilter(path path.Path, data *CoffeesFilter, filter **structs.Coffee) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Coffee{}
+	if *filter == nil {
+		*filter = target
+	} else {
+		target = *filter
+	}
+
+	if !data.Name.IsNull() {
+		target.Name = data.Name.ValueString()
+	}
+
+	if !data.Teaser.IsNull() {
+		target.Teaser = data.Teaser.ValueString()
+	}
+
+	return diags
+}
+
+func DecodeCustomers(ctx context.Context, getter Getter, customers **ListDataSource[structs.Customer]) diag.Diagnostics {
+	var data *Customers
+	diags := getter.Get(ctx, &data)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(decodeCustomers(path.Empty(), data, customers)...)
+	return diags
+}
+
+func decodeCustomers(path path.Path, data *Customers, customers **ListDataSource[structs.Customer]) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &ListDataSource[structs.Customer]{}
+	if *customers == nil {
+		*customers = target
+	} else {
+		target = *customers
+	}
+
+	if data.Items != nil {
+		target.Items = make([]structs.Customer, len(data.Items))
+		for i, data := range data.Items {
+			if data != nil {
+				var item *structs.Customer
+				diags.Append(decodeCustomer(path.AtName("customers").AtListIndex(i), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Items[i] = *item
+			}
+		}
+	}
+	return diags
+}
+
+func DecodeIngredients(ctx context.Context, getter Getter, ingredients **ListDataSource[structs.Ingredient]) diag.Diagnostics {
+	var data *Ingredients
+	diags := getter.Get(ctx, &data)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(decodeIngredients(path.Empty(), data, ingredients)...)
+	return diags
+}
+
+func decodeIngredients(path path.Path, data *Ingredients, ingredients **ListDataSource[structs.Ingredient]) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &ListDataSource[structs.Ingredient]{}
+	if *ingredients == nil {
+		*ingredients = target
+	} else {
+		target = *ingredients
+	}
+
+	if data.Items != nil {
+		target.Items = make([]structs.Ingredient, len(data.Items))
+		for i, data := range data.Items {
+			if data != nil {
+				var item *structs.Ingredient
+				diags.Append(decodeIngredient(path.AtName("ingredients").AtListIndex(i), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Items[i] = *item
+			}
+		}
+	}
+	return diags
+}
+
+func decodeLimits(path path.Path, data *Limits, limits **structs.Limits) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Limits{}
+	if *limits == nil {
+		*limits = target
+	} else {
+		target = *limits
+	}
+
+	if !data.Retries.IsNull() {
+		if n := data.Retries.ValueInt64(); n < math.MinInt8 || n > math.MaxInt8 {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("retries"), "invalid number", fmt.Sprintf("%d does not fit in an int8", n)))
+		} else {
+			target.Retries = int8(n)
+		}
+	}
+
+	if !data.Priority.IsNull() {
+		if n := data.Priority.ValueInt64(); n < math.MinInt16 || n > math.MaxInt16 {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("priority"), "invalid number", fmt.Sprintf("%d does not fit in an int16", n)))
+		} else {
+			v := int16(n)
+			target.Priority = &v
+		}
+	}
+
+	if !data.Port.IsNull() {
+		if n := data.Port.ValueInt64(); n < 0 || n > math.MaxUint16 {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("port"), "invalid number", fmt.Sprintf("%d does not fit in a uint16", n)))
+		} else {
+			target.Port = uint16(n)
+		}
+	}
+
+	if !data.Count.IsNull() {
+		if n := data.Count.ValueInt64(); n < 0 || uint64(n) > math.MaxUint {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("count"), "invalid number", fmt.Sprintf("%d does not fit in a uint", n)))
+		} else {
+			target.Count = uint(n)
+		}
+	}
+
+	if !data.Level.IsNull() {
+		if n := data.Level.ValueInt64(); n < math.MinInt32 || n > math.MaxInt32 {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("level"), "invalid number", fmt.Sprintf("%d does not fit in an int32", n)))
+		} else {
+			target.Level = int32(n)
+		}
+	}
+
+	if !data.Severity.IsNull() {
+		if n := data.Severity.ValueInt64(); n < math.MinInt8 || n > math.MaxInt8 {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("severity"), "invalid number", fmt.Sprintf("%d does not fit in an int8", n)))
+		} else {
+			target.Severity = structs.Severity(n)
+		}
+	}
+
+	if !data.Timeout.IsNull() {
+		n := data.Timeout.ValueInt64()
+		v := structs.Threshold(n)
+		target.Timeout = &v
+	}
+
+	if !data.Used.IsNull() {
+		if n := data.Used.ValueInt64(); n < math.MinInt8 || n > math.MaxInt8 {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("used"), "invalid number", fmt.Sprintf("%d does not fit in an int8", n)))
+		} else {
+			target.Used = int8(n)
+		}
+	}
+
+	return diags
+}
+
+func decodeCoffee(path path.Path, data *Coffee, coffee **structs.Coffee) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Coffee{}
+	if *coffee == nil {
+		*coffee = target
+	} else {
+		target = *coffee
+	}
+
+	if !data.ID.IsNull() {
+		if n := data.ID.ValueInt64(); n < math.MinInt || n > math.MaxInt {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("id"), "invalid number", fmt.Sprintf("%d does not fit in an int", n)))
+		} else {
+			target.ID = int(n)
+		}
+	}
+
+	if !data.Name.IsNull() {
+		target.Name = data.Name.ValueString()
+	}
+
+	if !data.Teaser.IsNull() {
+		target.Teaser = data.Teaser.ValueString()
+	}
+
+	if !data.Description.IsNull() {
+		target.Description = data.Description.ValueString()
+	}
+
+	if !data.Image.IsNull() {
+		target.Image = data.Image.ValueString()
+	}
+
+	if data.Ingredients != nil {
+		target.Ingredients = make([]structs.Ingredient, len(data.Ingredients))
+		for i, data := range data.Ingredients {
+			if data != nil {
+				var item *structs.Ingredient
+				diags.Append(decodeIngredient(path.AtName("ingredients").AtListIndex(i), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Ingredients[i] = *item
+			}
+		}
+	}
+
+	if data.Customer != nil {
+		var item *structs.Customer
+		diags.Append(decodeCustomer(path.AtName("customer"), data.Customer, &item)...)
+
+		if diags.HasError() {
+			return diags
+		}
+
+		target.Customer = item
+	}
+
+	if data.Tags != nil {
+		target.Tags = make([]string, len(data.Tags))
+		for i, data := range data.Tags {
+			if !data.IsNull() {
+				target.Tags[i] = data.ValueString()
+			}
+		}
+	}
+
+	if data.Baristas != nil {
+		target.Baristas = make([]structs.Customer, len(data.Baristas))
+		for i, data := range data.Baristas {
+			if data != nil {
+				var item *structs.Customer
+				diags.Append(decodeCustomer(path.AtName("baristas"), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Baristas[i] = *item
+			}
+		}
+	}
+
+	if data.Suppliers != nil {
+		target.Suppliers = map[string]structs.Customer{}
+		for key, data := range data.Suppliers {
+			if data != nil {
+				var item *structs.Customer
+				diags.Append(decodeCustomer(path.AtName("suppliers").AtMapKey(key), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Suppliers[key] = *item
+			}
+		}
+	}
+
+	if data.Origins != nil {
+		target.Origins = map[string]*structs.Ingredient{}
+		for key, data := range data.Origins {
+			if data != nil {
+				var item *structs.Ingredient
+				diags.Append(decodeIngredient(path.AtName("origins").AtMapKey(key), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Origins[key] = item
+			}
+		}
+	}
+
+	return diags
+}
+
+func decodeCustomer(path path.Path, data *Customer, customer **structs.Customer) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Customer{}
+	if *customer == nil {
+		*customer = target
+	} else {
+		target = *customer
+	}
+
+	if !data.ID.IsNull() {
+		n := data.ID.ValueInt64()
+		target.ID = n
+	}
+
+	if !data.Name.IsNull() {
+		target.Name = data.Name.ValueString()
+	}
+
+	return diags
+}
+
+func decodeIngredient(path path.Path, data *Ingredient, ingredient **structs.Ingredient) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Ingredient{}
+	if *ingredient == nil {
+		*ingredient = target
+	} else {
+		target = *ingredient
+	}
+
+	if !data.ID.IsNull() {
+		if n := data.ID.ValueInt64(); n < math.MinInt || n > math.MaxInt {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("id"), "invalid number", fmt.Sprintf("%d does not fit in an int", n)))
+		} else {
+			target.ID = int(n)
+		}
+	}
+
+	if !data.Float32.IsNull() {
+		n := float32(data.Float32.ValueFloat64())
+		target.Float32 = n
+	}
+
+	if !data.Float64.IsNull() {
+		n := data.Float64.ValueFloat64()
+		target.Float64 = n
+	}
+
+	return diags
+}
@@ -0,0 +1,284 @@
+/*
+Code generated by github-terraform-generator; DO NOT EDIT.
+Any modifications will be overwritten
+*/
+// Generator: models
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+	structs "github.com/Lenstra/terraform-plugin-generator/tests/structs"
+	diag "github.com/hashicorp/terraform-plugin-framework/diag"
+	path "github.com/hashicorp/terraform-plugin-framework/path"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Setter interface {
+	Set(context.Context, interface{}) diag.Diagnostics
+}
+
+func Set[Model *structs.Limits | *ListDataSource[structs.Coffee] | *ListDataSource[structs.Customer] | *ListDataSource[structs.Ingredient]](ctx context.Context, setter Setter, obj Model) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var converted interface{}
+	switch o := any(obj).(type) {
+	case *structs.Limits:
+		converted, diags = EncodeLimits(o)
+	case *ListDataSource[structs.Coffee]:
+		converted, diags = EncodeCoffees(o)
+	case *ListDataSource[structs.Customer]:
+		converted, diags = EncodeCustomers(o)
+	case *ListDataSource[structs.Ingredient]:
+		converted, diags = EncodeIngredients(o)
+	default:
+		diags.AddError("unsupported object type", fmt.Sprintf("%T is not supported in %s.Set(). Please report this issue to the provider developers.", obj, "datasources"))
+		return diags
+	}
+	diags.Append(setter.Set(ctx, converted)...)
+	return diags
+}
+
+func EncodeLimits(limits *structs.Limits) (*Limits, diag.Diagnostics) {
+	return encodeLimits(path.Empty(), limits)
+}
+
+func encodeLimits(path path.Path, limits *structs.Limits) (*Limits, diag.Diagnostics) {
+	if limits == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Limits{}
+	res.Retries = types.Int64Value(int64(limits.Retries))
+	if limits.Priority != nil {
+		res.Priority = types.Int64Value(int64(*limits.Priority))
+	}
+	res.Port = types.Int64Value(int64(limits.Port))
+	res.Count = types.Int64Value(int64(limits.Count))
+	res.Level = types.Int64Value(int64(limits.Level))
+	res.Severity = types.Int64Value(int64(limits.Severity))
+	if limits.Timeout != nil {
+		res.Timeout = types.Int64Value(int64(*limits.Timeout))
+	}
+	res.Used = types.Int64Value(int64(limits.Used))
+	return &res, diags
+}
+
+func encodeCoffee(path path.Path, coffee *structs.Coffee) (*Coffee, diag.Diagnostics) {
+	if coffee == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Coffee{}
+	res.ID = types.Int64Value(int64(coffee.ID))
+	res.Name = types.StringValue(coffee.Name)
+	res.Teaser = types.StringValue(coffee.Teaser)
+	res.Description = types.StringValue(coffee.Description)
+	res.Image = types.StringValue(coffee.Image)
+	if coffee.Ingredients != nil {
+		res.Ingredients = make([]*Ingredient, len(coffee.Ingredients))
+		for i, attr := range coffee.Ingredients {
+			{
+				data, d := encodeIngredient(path.AtName("ingredients").AtListIndex(i), &attr)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Ingredients[i] = data
+				}
+			}
+		}
+	}
+	{
+		data, d := encodeCustomer(path.AtName("customer"), coffee.Customer)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		if data != nil {
+			res.Customer = data
+		}
+	}
+	if coffee.Tags != nil {
+		res.Tags = make([]types.String, len(coffee.Tags))
+		for i, attr := range coffee.Tags {
+			res.Tags[i] = types.StringValue(attr)
+		}
+	}
+	if coffee.Baristas != nil {
+		res.Baristas = make([]*Customer, len(coffee.Baristas))
+		for i, attr := range coffee.Baristas {
+			{
+				data, d := encodeCustomer(path.AtName("baristas"), &attr)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Baristas[i] = data
+				}
+			}
+		}
+	}
+	if coffee.Suppliers != nil {
+		res.Suppliers = map[string]*Customer{}
+		for k, v := range coffee.Suppliers {
+			{
+				data, d := encodeCustomer(path.AtName("suppliers").AtMapKey(k), &v)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Suppliers[k] = data
+				}
+			}
+		}
+	}
+	if coffee.Origins != nil {
+		res.Origins = map[string]*Ingredient{}
+		for k, v := range coffee.Origins {
+			{
+				data, d := encodeIngredient(path.AtName("origins").AtMapKey(k), v)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Origins[k] = data
+				}
+			}
+		}
+	}
+	return &res, diags
+}
+
+func encodeCustomer(path path.Path, customer *structs.Customer) (*Customer, diag.Diagnostics) {
+	if customer == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Customer{}
+	res.ID = types.Int64Value(customer.ID)
+	res.Name = types.StringValue(customer.Name)
+	return &res, diags
+}
+
+func encodeIngredient(path path.Path, ingredient *structs.Ingredient) (*Ingredient, diag.Diagnostics) {
+	if ingredient == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Ingredient{}
+	res.ID = types.Int64Value(int64(ingredient.ID))
+	res.Float32 = types.Float64Value(float64(ingredient.Float32))
+	res.Float64 = types.Float64Value(float64(ingredient.Float64))
+	return &res, diags
+}
+
+func EncodeCoffees(coffees *ListDataSource[structs.Coffee]) (*Coffees, diag.Diagnostics) {
+	return encodeCoffees(path.Empty(), coffees)
+}
+
+func encodeCoffees(path path.Path, coffees *ListDataSource[structs.Coffee]) (*Coffees, diag.Diagnostics) {
+	if coffees == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Coffees{}
+	if coffees.Items != nil {
+		res.Items = make([]*Coffee, len(coffees.Items))
+		for i, attr := range coffees.Items {
+			{
+				data, d := encodeCoffee(path.AtName("coffees").AtListIndex(i), &attr)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Items[i] = data
+				}
+			}
+		}
+	}
+	filter, d := encodeCoffeesFilter(path.AtName("filter"), coffees.Filter)
+	diags.Append(d...)
+	res.Filter = filter
+	return &res, diags
+}
+
+func encodeCoffeesFilter(path path.Path, filter *structs.Coffee) (*CoffeesFilter, diag.Diagnostics) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := CoffeesFilter{}
+	res.Name = types.StringValue(filter.Name)
+	res.Teaser = types.StringValue(filter.Teaser)
+	return &res, diags
+}
+
+func EncodeCustomers(customers *ListDataSource[structs.Customer]) (*Customers, diag.Diagnostics) {
+	return encodeCustomers(path.Empty(), customers)
+}
+
+func encodeCustomers(path path.Path, customers *ListDataSource[structs.Customer]) (*Customers, diag.Diagnostics) {
+	if customers == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Customers{}
+	if customers.Items != nil {
+		res.Items = make([]*Customer, len(customers.Items))
+		for i, attr := range customers.Items {
+			{
+				data, d := encodeCustomer(path.AtName("customers").AtListIndex(i), &attr)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Items[i] = data
+				}
+			}
+		}
+	}
+	return &res, diags
+}
+
+func EncodeIngredients(ingredients *ListDataSource[structs.Ingredient]) (*Ingredients, diag.Diagnostics) {
+	return encodeIngredients(path.Empty(), ingredients)
+}
+
+func encodeIngredients(path path.Path, ingredients *ListDataSource[structs.Ingredient]) (*Ingredients, diag.Diagnostics) {
+	if ingredients == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Ingredients{}
+	if ingredients.Items != nil {
+		res.Items = make([]*Ingredient, len(ingredients.Items))
+		for i, attr := range ingredients.Items {
+			{
+				data, d := encodeIngredient(path.AtName("ingredients").AtListIndex(i), &attr)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Items[i] = data
+				}
+			}
+		}
+	}
+	return &res, diags
+}
@@ -0,0 +1,69 @@
+/*
+Code generated by github-terraform-generator; DO NOT EDIT.
+Any modifications will be overwritten
+*/
+// Generator: models
+
+package datasources
+
+import types "github.com/hashicorp/terraform-plugin-framework/types"
+
+type Limits struct {
+	Retries  types.Int64 `tfsdk:"retries"`
+	Priority types.Int64 `tfsdk:"priority"`
+	Port     types.Int64 `tfsdk:"port"`
+	Count    types.Int64 `tfsdk:"count"`
+	Level    types.Int64 `tfsdk:"level"`
+	Severity types.Int64 `tfsdk:"severity"`
+	Timeout  types.Int64 `tfsdk:"timeout"`
+	Used     types.Int64 `tfsdk:"used"`
+}
+
+type Coffee struct {
+	ID          types.Int64            `tfsdk:"id"`
+	Name        types.String           `tfsdk:"name"`
+	Teaser      types.String           `tfsdk:"teaser"`
+	Description types.String           `tfsdk:"description"`
+	Image       types.String           `tfsdk:"image"`
+	Ingredients []*Ingredient          `tfsdk:"ingredients"`
+	Customer    *Customer              `tfsdk:"customer"`
+	Tags        []types.String         `tfsdk:"tags"`
+	Baristas    []*Customer            `tfsdk:"baristas"`
+	Suppliers   map[string]*Customer   `tfsdk:"suppliers"`
+	Origins     map[string]*Ingredient `tfsdk:"origins"`
+}
+
+type Customer struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+type Ingredient struct {
+	ID      types.Int64   `tfsdk:"id"`
+	Float32 types.Float64 `tfsdk:"float32"`
+	Float64 types.Float64 `tfsdk:"float64"`
+}
+
+// ListDataSource is the Go representation of the list data sources
+type ListDataSource[T any] struct {
+	Items  []T
+	Filter *T
+}
+
+type Coffees struct {
+	Items  []*Coffee      `tfsdk:"coffees"`
+	Filter *CoffeesFilter `tfsdk:"filter"`
+}
+
+type CoffeesFilter struct {
+	Name   types.String `tfsdk:"name"`
+	Teaser types.String `tfsdk:"teaser"`
+}
+
+type Customers struct {
+	Items []*Customer `tfsdk:"customers"`
+}
+
+type Ingredients struct {
+	Items []*Ingredient `tfsdk:"ingredients"`
+}
@@ -0,0 +1,81 @@
+package datasources
+
+import (
+	"context"
+	"testing"
+
+	structs "github.com/Lenstra/terraform-plugin-generator/tests/structs"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	customers := &ListDataSource[structs.Customer]{
+		Items: []structs.Customer{
+			{ID: 1, Name: "alice"},
+			{ID: 2, Name: "bob"},
+		},
+	}
+
+	// The model of a list data source without filterable attributes must
+	// match its schema that has no filter block
+	s := customersSchema()
+	state := &tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+	diags := Set(ctx, state, customers)
+	require.False(t, diags.HasError(), diags)
+
+	var roundTrip *ListDataSource[structs.Customer]
+	diags = Decode(ctx, state, &roundTrip)
+	require.False(t, diags.HasError(), diags)
+
+	require.Equal(t, customers, roundTrip)
+}
+
+func TestLookupBounds(t *testing.T) {
+	// The lookup keys are given by the user, the values that do not fit in
+	// their Go type are rejected at plan time
+	ctx := context.Background()
+	attribute := limitsSchema().Attributes["retries"].(schema.Int64Attribute)
+	require.True(t, attribute.Optional)
+	req := validator.Int64Request{Path: path.Root("retries"), ConfigValue: types.Int64Value(300)}
+	resp := &validator.Int64Response{}
+	for _, v := range attribute.Validators {
+		v.ValidateInt64(ctx, req, resp)
+	}
+	require.True(t, resp.Diagnostics.HasError())
+
+	// The other attributes are computed and have no validators
+	require.Empty(t, limitsSchema().Attributes["port"].(schema.Int64Attribute).Validators)
+}
+
+func TestListDataSourceDocumentation(t *testing.T) {
+	// The list data sources use the documentation of their elements
+	require.Equal(t, "Coffee is a drink served by the coffee shop.", coffeesSchema().MarkdownDescription)
+	require.Equal(t, "Ingredients are now managed by the coffee resource.", ingredientsSchema().DeprecationMessage)
+	require.Empty(t, coffeesSchema().DeprecationMessage)
+}
+
+func TestListDataSourceSchema(t *testing.T) {
+	s := coffeesSchema()
+	coffees := s.Attributes["coffees"].(*schema.ListNestedAttribute)
+	require.True(t, coffees.Computed)
+
+	// The filterable attributes are optional in the filter block
+	filter := s.Blocks["filter"].(schema.SingleNestedBlock)
+	require.True(t, filter.Attributes["name"].(schema.StringAttribute).Optional)
+	require.NotContains(t, filter.Attributes, "image")
+
+	// The lookup keys are only used by the data source of a single object
+	id := coffees.NestedObject.Attributes["id"].(schema.Int64Attribute)
+	require.True(t, id.Computed)
+	require.False(t, id.Optional)
+}
@@ -0,0 +1,228 @@
+/*
+Code generated by github-terraform-generator; DO NOT EDIT.
+Any modifications will be overwritten
+*/
+// Generator: datasourcefromresource schema
+
+package datasources
+
+import (
+	int64validator "github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	validator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func limitsSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Limits holds integers narrower than an int64.",
+		Attributes: map[string]schema.Attribute{
+			"retries": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Between(-128, 127),
+				},
+			},
+			"priority": schema.Int64Attribute{
+				Computed: true,
+			},
+			"port": schema.Int64Attribute{
+				Computed: true,
+			},
+			"count": schema.Int64Attribute{
+				Computed: true,
+			},
+			"level": schema.Int64Attribute{
+				Computed: true,
+			},
+			"severity": schema.Int64Attribute{
+				Computed: true,
+			},
+			"timeout": schema.Int64Attribute{
+				Computed: true,
+			},
+			"used": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{},
+	}
+}
+
+func coffeesSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Coffee is a drink served by the coffee shop.",
+		Attributes: map[string]schema.Attribute{
+			"coffees": &schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Coffee is a drink served by the coffee shop.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "ID is the identifier of the coffee.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name is the name displayed on the menu.",
+						},
+						"teaser": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "How strong the coffee is, either mild or strong.",
+						},
+						"description": schema.StringAttribute{
+							Computed:           true,
+							DeprecationMessage: "Use teaser instead, description will be removed.",
+						},
+						"image": schema.StringAttribute{
+							Computed: true,
+						},
+						"ingredients": &schema.ListNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.Int64Attribute{
+										Computed: true,
+									},
+									"float32": schema.Float64Attribute{
+										Computed: true,
+									},
+									"float64": schema.Float64Attribute{
+										Computed: true,
+									},
+								}},
+						},
+						"customer": &schema.SingleNestedAttribute{
+							Computed: true,
+							Attributes: map[string]schema.Attribute{
+								"id": schema.Int64Attribute{
+									Computed:            true,
+									MarkdownDescription: "The identifier of the customer.",
+								},
+								"name": schema.StringAttribute{
+									Computed:            true,
+									MarkdownDescription: "Name of the customer.",
+									DeprecationMessage:  "the API does not return the name of the customers anymore.",
+								},
+							},
+						},
+						"tags": schema.SetAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"baristas": &schema.SetNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.Int64Attribute{
+										Computed:            true,
+										MarkdownDescription: "The identifier of the customer.",
+									},
+									"name": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Name of the customer.",
+										DeprecationMessage:  "the API does not return the name of the customers anymore.",
+									},
+								}},
+						},
+						"suppliers": &schema.MapNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.Int64Attribute{
+										Computed:            true,
+										MarkdownDescription: "The identifier of the customer.",
+									},
+									"name": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Name of the customer.",
+										DeprecationMessage:  "the API does not return the name of the customers anymore.",
+									},
+								},
+							},
+						},
+						"origins": &schema.MapNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.Int64Attribute{
+										Computed: true,
+									},
+									"float32": schema.Float64Attribute{
+										Computed: true,
+									},
+									"float64": schema.Float64Attribute{
+										Computed: true,
+									},
+								},
+							},
+						},
+					}},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Name is the name displayed on the menu.",
+					},
+					"teaser": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "How strong the coffee is, either mild or strong.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func customersSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "",
+		Attributes: map[string]schema.Attribute{
+			"customers": &schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The identifier of the customer.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the customer.",
+							DeprecationMessage:  "the API does not return the name of the customers anymore.",
+						},
+					}},
+			},
+		},
+		Blocks: map[string]schema.Block{},
+	}
+}
+
+func ingredientsSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "",
+		DeprecationMessage:  "Ingredients are now managed by the coffee resource.",
+		Attributes: map[string]schema.Attribute{
+			"ingredients": &schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed: true,
+						},
+						"float32": schema.Float64Attribute{
+							Computed: true,
+						},
+						"float64": schema.Float64Attribute{
+							Computed: true,
+						},
+					}},
+			},
+		},
+		Blocks: map[string]schema.Block{},
+	}
+}
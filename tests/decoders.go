@@ -2,29 +2,48 @@
 Code generated by github-terraform-generator; DO NOT EDIT.
 Any modifications will be overwritten
 */
+// Generator: models
 
 package tests
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	structs "github.com/Lenstra/terraform-plugin-generator/tests/structs"
+	attr "github.com/hashicorp/terraform-plugin-framework/attr"
 	diag "github.com/hashicorp/terraform-plugin-framework/diag"
 	path "github.com/hashicorp/terraform-plugin-framework/path"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+	"math"
+	"math/big"
+	"strconv"
 )
 
 type Getter interface {
 	Get(context.Context, interface{}) diag.Diagnostics
 }
 
-func Decode[Target **structs.Coffee | **structs.Config | **structs.Ingredient](ctx context.Context, getter Getter, obj Target) diag.Diagnostics {
+func Decode[Target **structs.Account | **structs.Coffee | **structs.Config | **structs.Event | **structs.Ingredient | **structs.Invoice | **structs.Limits | **structs.Order | **ListDataSource[structs.Coffee]](ctx context.Context, getter Getter, obj Target) diag.Diagnostics {
 	switch o := any(obj).(type) {
+	case **structs.Account:
+		return DecodeAccount(ctx, getter, o)
 	case **structs.Coffee:
 		return DecodeCoffee(ctx, getter, o)
 	case **structs.Config:
 		return DecodeConfig(ctx, getter, o)
+	case **structs.Event:
+		return DecodeEvent(ctx, getter, o)
 	case **structs.Ingredient:
 		return DecodeIngredient(ctx, getter, o)
+	case **structs.Invoice:
+		return DecodeInvoice(ctx, getter, o)
+	case **structs.Limits:
+		return DecodeLimits(ctx, getter, o)
+	case **structs.Order:
+		return DecodeOrder(ctx, getter, o)
+	case **ListDataSource[structs.Coffee]:
+		return DecodeCoffees(ctx, getter, o)
 	default:
 		var diags diag.Diagnostics
 		diags.AddError("unsupported object type", fmt.Sprintf("%T is not supported in %s.Set(). Please report this issue to the provider developers.", obj, "tests"))
@@ -32,6 +51,17 @@ func Decode[Target **structs.Coffee | **structs.Config | **structs.Ingredient](c
 	}
 }
 
+func DecodeAccount(ctx context.Context, getter Getter, account **structs.Account) diag.Diagnostics {
+	var data *Account
+	diags := getter.Get(ctx, &data)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(decodeAccount(path.Empty(), data, account)...)
+	return diags
+}
+
 func DecodeCoffee(ctx context.Context, getter Getter, coffee **structs.Coffee) diag.Diagnostics {
 	var data *Coffee
 	diags := getter.Get(ctx, &data)
@@ -54,6 +84,17 @@ func DecodeConfig(ctx context.Context, getter Getter, config **structs.Config) d
 	return diags
 }
 
+func DecodeEvent(ctx context.Context, getter Getter, event **structs.Event) diag.Diagnostics {
+	var data *Event
+	diags := getter.Get(ctx, &data)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(decodeEvent(path.Empty(), data, event)...)
+	return diags
+}
+
 func DecodeIngredient(ctx context.Context, getter Getter, ingredient **structs.Ingredient) diag.Diagnostics {
 	var data *Ingredient
 	diags := getter.Get(ctx, &data)
@@ -65,6 +106,145 @@ func DecodeIngredient(ctx context.Context, getter Getter, ingredient **structs.I
 	return diags
 }
 
+func DecodeInvoice(ctx context.Context, getter Getter, invoice **structs.Invoice) diag.Diagnostics {
+	var data *Invoice
+	diags := getter.Get(ctx, &data)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(decodeInvoice(path.Empty(), data, invoice)...)
+	return diags
+}
+
+func DecodeLimits(ctx context.Context, getter Getter, limits **structs.Limits) diag.Diagnostics {
+	var data *Limits
+	diags := getter.Get(ctx, &data)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(decodeLimits(path.Empty(), data, limits)...)
+	return diags
+}
+
+func DecodeOrder(ctx context.Context, getter Getter, order **structs.Order) diag.Diagnostics {
+	var data *Order
+	diags := getter.Get(ctx, &data)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(decodeOrder(path.Empty(), data, order)...)
+	return diags
+}
+
+func DecodeCoffees(ctx context.Context, getter Getter, coffees **ListDataSource[structs.Coffee]) diag.Diagnostics {
+	var data *Coffees
+	diags := getter.Get(ctx, &data)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(decodeCoffees(path.Empty(), data, coffees)...)
+	return diags
+}
+
+func decodeCoffees(path path.Path, data *Coffees, coffees **ListDataSource[structs.Coffee]) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &ListDataSource[structs.Coffee]{}
+	if *coffees == nil {
+		*coffees = target
+	} else {
+		target = *coffees
+	}
+
+	if data.Items != nil {
+		target.Items = make([]structs.Coffee, len(data.Items))
+		for i, data := range data.Items {
+			if data != nil {
+				var item *structs.Coffee
+				diags.Append(decodeCoffee(path.AtName("coffees").AtListIndex(i), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Items[i] = *item
+			}
+		}
+	}
+
+	diags.Append(decodeCoffeesFilter(path.AtName("filter"), data.Filter, &target.Filter)...)
+	return diags
+}
+
+func decodeCoffeesFilter(path path.Path, data *CoffeesFilter, filter **structs.Coffee) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Coffee{}
+	if *filter == nil {
+		*filter = target
+	} else {
+		target = *filter
+	}
+
+	if !data.Name.IsNull() {
+		target.Name = data.Name.ValueString()
+	}
+
+	if !data.Teaser.IsNull() {
+		target.Teaser = data.Teaser.ValueString()
+	}
+
+	return diags
+}
+
+func decodeAccount(path path.Path, data *Account, account **structs.Account) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Account{}
+	if *account == nil {
+		*account = target
+	} else {
+		target = *account
+	}
+
+	if !data.Email.IsNull() {
+		target.Email = data.Email.ValueString()
+	}
+
+	if !data.Name.IsNull() {
+		target.Metadata.Name = data.Name.ValueString()
+	}
+
+	if target.Metadata.Audit == nil {
+		target.Metadata.Audit = &structs.Audit{}
+	}
+
+	if !data.CreatedBy.IsNull() {
+		target.Metadata.Audit.CreatedBy = data.CreatedBy.ValueString()
+	}
+
+	if data.Labels != nil {
+		target.Metadata.Labels = map[string]string{}
+		for key, data := range data.Labels {
+			if !data.IsNull() {
+				target.Metadata.Labels[key] = data.ValueString()
+			}
+		}
+	}
+
+	return diags
+}
+
 func decodeCoffee(path path.Path, data *Coffee, coffee **structs.Coffee) (diags diag.Diagnostics) {
 	if data == nil {
 		return nil
@@ -78,8 +258,11 @@ func decodeCoffee(path path.Path, data *Coffee, coffee **structs.Coffee) (diags
 	}
 
 	if !data.ID.IsNull() {
-		n := int(data.ID.ValueInt64())
-		target.ID = n
+		if n := data.ID.ValueInt64(); n < math.MinInt || n > math.MaxInt {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("id"), "invalid number", fmt.Sprintf("%d does not fit in an int", n)))
+		} else {
+			target.ID = int(n)
+		}
 	}
 
 	if !data.Name.IsNull() {
@@ -125,6 +308,63 @@ func decodeCoffee(path path.Path, data *Coffee, coffee **structs.Coffee) (diags
 		target.Customer = item
 	}
 
+	if data.Tags != nil {
+		target.Tags = make([]string, len(data.Tags))
+		for i, data := range data.Tags {
+			if !data.IsNull() {
+				target.Tags[i] = data.ValueString()
+			}
+		}
+	}
+
+	if data.Baristas != nil {
+		target.Baristas = make([]structs.Customer, len(data.Baristas))
+		for i, data := range data.Baristas {
+			if data != nil {
+				var item *structs.Customer
+				diags.Append(decodeCustomer(path.AtName("baristas"), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Baristas[i] = *item
+			}
+		}
+	}
+
+	if data.Suppliers != nil {
+		target.Suppliers = map[string]structs.Customer{}
+		for key, data := range data.Suppliers {
+			if data != nil {
+				var item *structs.Customer
+				diags.Append(decodeCustomer(path.AtName("suppliers").AtMapKey(key), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Suppliers[key] = *item
+			}
+		}
+	}
+
+	if data.Origins != nil {
+		target.Origins = map[string]*structs.Ingredient{}
+		for key, data := range data.Origins {
+			if data != nil {
+				var item *structs.Ingredient
+				diags.Append(decodeIngredient(path.AtName("origins").AtMapKey(key), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Origins[key] = item
+			}
+		}
+	}
+
 	return diags
 }
 
@@ -144,13 +384,24 @@ func decodeConfig(path path.Path, data *Config, config **structs.Config) (diags
 		target.Host = data.Host.ValueString()
 	}
 
+	if !data.Password.IsNull() {
+		target.Password = data.Password.ValueString()
+	}
+
+	if !data.Token.IsNull() {
+		target.Token = data.Token.ValueString()
+	}
+
 	if !data.Bool.IsNull() {
 		target.PromotedBool.Bool = data.Bool.ValueBool()
 	}
 
 	if !data.Int.IsNull() {
-		n := int(data.Int.ValueInt64())
-		target.PromotedInt.Int = n
+		if n := data.Int.ValueInt64(); n < math.MinInt || n > math.MaxInt {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("int"), "invalid number", fmt.Sprintf("%d does not fit in an int", n)))
+		} else {
+			target.PromotedInt.Int = int(n)
+		}
 	}
 
 	if !data.String.IsNull() {
@@ -160,6 +411,48 @@ func decodeConfig(path path.Path, data *Config, config **structs.Config) (diags
 	return diags
 }
 
+func decodeEvent(path path.Path, data *Event, event **structs.Event) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Event{}
+	if *event == nil {
+		*event = target
+	} else {
+		target = *event
+	}
+
+	if !data.Name.IsNull() {
+		target.Name = data.Name.ValueString()
+	}
+
+	if !data.Data.IsNull() {
+		v, d := decodeDynamicValue(path.AtName("data"), data.Data.UnderlyingValue())
+		diags.Append(d...)
+		target.Data = v
+	}
+
+	if !data.Details.IsNull() {
+		v, d := decodeDynamicValue(path.AtName("details"), data.Details.UnderlyingValue())
+		diags.Append(d...)
+		target.Details = v
+	}
+
+	if data.Source != nil {
+		var item *structs.Source
+		diags.Append(decodeSource(path.AtName("source"), data.Source, &item)...)
+
+		if diags.HasError() {
+			return diags
+		}
+
+		target.Source = item
+	}
+
+	return diags
+}
+
 func decodeIngredient(path path.Path, data *Ingredient, ingredient **structs.Ingredient) (diags diag.Diagnostics) {
 	if data == nil {
 		return nil
@@ -173,8 +466,11 @@ func decodeIngredient(path path.Path, data *Ingredient, ingredient **structs.Ing
 	}
 
 	if !data.ID.IsNull() {
-		n := int(data.ID.ValueInt64())
-		target.ID = n
+		if n := data.ID.ValueInt64(); n < math.MinInt || n > math.MaxInt {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("id"), "invalid number", fmt.Sprintf("%d does not fit in an int", n)))
+		} else {
+			target.ID = int(n)
+		}
 	}
 
 	if !data.Float32.IsNull() {
@@ -190,6 +486,219 @@ func decodeIngredient(path path.Path, data *Ingredient, ingredient **structs.Ing
 	return diags
 }
 
+func decodeInvoice(path path.Path, data *Invoice, invoice **structs.Invoice) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Invoice{}
+	if *invoice == nil {
+		*invoice = target
+	} else {
+		target = *invoice
+	}
+
+	if f := data.Total.ValueBigFloat(); f != nil {
+		if !f.IsInt() {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("total"), "invalid number", fmt.Sprintf("%s is not an integer", f.Text('g', -1))))
+		} else {
+			n, _ := f.Int(nil)
+			target.Total = n
+		}
+	}
+
+	if f := data.Rate.ValueBigFloat(); f != nil {
+		target.Rate = new(big.Float).Copy(f)
+	}
+
+	if f := data.Balance.ValueBigFloat(); f != nil {
+		if !f.IsInt() {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("balance"), "invalid number", fmt.Sprintf("%s is not an integer", f.Text('g', -1))))
+		} else {
+			n, _ := f.Int(nil)
+			target.Balance = *n
+		}
+	}
+
+	if f := data.Amount.ValueBigFloat(); f != nil {
+		target.Amount = json.Number(f.Text('f', -1))
+	}
+
+	if f := data.Sequence.ValueBigFloat(); f != nil {
+		if n, accuracy := f.Uint64(); accuracy != big.Exact {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("sequence"), "invalid number", fmt.Sprintf("%s does not fit in a uint64", f.Text('g', -1))))
+		} else {
+			target.Sequence = n
+		}
+	}
+
+	if f := data.Previous.ValueBigFloat(); f != nil {
+		if n, accuracy := f.Uint64(); accuracy != big.Exact {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("previous"), "invalid number", fmt.Sprintf("%s does not fit in a uint64", f.Text('g', -1))))
+		} else {
+			target.Previous = &n
+		}
+	}
+
+	if data.Lines != nil {
+		target.Lines = make([]structs.InvoiceLine, len(data.Lines))
+		for i, data := range data.Lines {
+			if data != nil {
+				var item *structs.InvoiceLine
+				diags.Append(decodeInvoiceLine(path.AtName("lines").AtListIndex(i), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Lines[i] = *item
+			}
+		}
+	}
+
+	return diags
+}
+
+func decodeLimits(path path.Path, data *Limits, limits **structs.Limits) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Limits{}
+	if *limits == nil {
+		*limits = target
+	} else {
+		target = *limits
+	}
+
+	if !data.Retries.IsNull() {
+		if n := data.Retries.ValueInt64(); n < math.MinInt8 || n > math.MaxInt8 {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("retries"), "invalid number", fmt.Sprintf("%d does not fit in an int8", n)))
+		} else {
+			target.Retries = int8(n)
+		}
+	}
+
+	if !data.Priority.IsNull() {
+		if n := data.Priority.ValueInt64(); n < math.MinInt16 || n > math.MaxInt16 {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("priority"), "invalid number", fmt.Sprintf("%d does not fit in an int16", n)))
+		} else {
+			v := int16(n)
+			target.Priority = &v
+		}
+	}
+
+	if !data.Port.IsNull() {
+		if n := data.Port.ValueInt64(); n < 0 || n > math.MaxUint16 {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("port"), "invalid number", fmt.Sprintf("%d does not fit in a uint16", n)))
+		} else {
+			target.Port = uint16(n)
+		}
+	}
+
+	if !data.Count.IsNull() {
+		if n := data.Count.ValueInt64(); n < 0 || uint64(n) > math.MaxUint {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("count"), "invalid number", fmt.Sprintf("%d does not fit in a uint", n)))
+		} else {
+			target.Count = uint(n)
+		}
+	}
+
+	if !data.Level.IsNull() {
+		if n := data.Level.ValueInt64(); n < math.MinInt32 || n > math.MaxInt32 {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("level"), "invalid number", fmt.Sprintf("%d does not fit in an int32", n)))
+		} else {
+			target.Level = int32(n)
+		}
+	}
+
+	if !data.Severity.IsNull() {
+		if n := data.Severity.ValueInt64(); n < math.MinInt8 || n > math.MaxInt8 {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("severity"), "invalid number", fmt.Sprintf("%d does not fit in an int8", n)))
+		} else {
+			target.Severity = structs.Severity(n)
+		}
+	}
+
+	if !data.Timeout.IsNull() {
+		n := data.Timeout.ValueInt64()
+		v := structs.Threshold(n)
+		target.Timeout = &v
+	}
+
+	if !data.Used.IsNull() {
+		if n := data.Used.ValueInt64(); n < math.MinInt8 || n > math.MaxInt8 {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("used"), "invalid number", fmt.Sprintf("%d does not fit in an int8", n)))
+		} else {
+			target.Used = int8(n)
+		}
+	}
+
+	return diags
+}
+
+func decodeOrder(path path.Path, data *Order, order **structs.Order) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Order{}
+	if *order == nil {
+		*order = target
+	} else {
+		target = *order
+	}
+
+	if !data.Options.IsNull() {
+		var v map[string]interface{}
+		if err := json.Unmarshal([]byte(data.Options.ValueString()), &v); err != nil {
+			diags.AddAttributeError(path.AtName("options"), "failed to unmarshal json", err.Error())
+		} else {
+			target.Options = v
+		}
+	}
+
+	if !data.Steps.IsNull() {
+		var v []interface{}
+		if err := json.Unmarshal([]byte(data.Steps.ValueString()), &v); err != nil {
+			diags.AddAttributeError(path.AtName("steps"), "failed to unmarshal json", err.Error())
+		} else {
+			target.Steps = v
+		}
+	}
+
+	if !data.Raw.IsNull() {
+		target.Raw = json.RawMessage(data.Raw.ValueString())
+	}
+
+	if !data.Payload.IsNull() {
+		var v structs.Payload
+		if err := json.Unmarshal([]byte(data.Payload.ValueString()), &v); err != nil {
+			diags.AddAttributeError(path.AtName("payload"), "failed to unmarshal json", err.Error())
+		} else {
+			target.Payload = v
+		}
+	}
+
+	if data.Lines != nil {
+		target.Lines = make([]structs.OrderLine, len(data.Lines))
+		for i, data := range data.Lines {
+			if data != nil {
+				var item *structs.OrderLine
+				diags.Append(decodeOrderLine(path.AtName("lines").AtListIndex(i), data, &item)...)
+
+				if diags.HasError() {
+					return diags
+				}
+
+				target.Lines[i] = *item
+			}
+		}
+	}
+
+	return diags
+}
+
 func decodeCustomer(path path.Path, data *Customer, customer **structs.Customer) (diags diag.Diagnostics) {
 	if data == nil {
 		return nil
@@ -213,3 +722,154 @@ func decodeCustomer(path path.Path, data *Customer, customer **structs.Customer)
 
 	return diags
 }
+
+func decodeSource(path path.Path, data *Source, source **structs.Source) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Source{}
+	if *source == nil {
+		*source = target
+	} else {
+		target = *source
+	}
+
+	if !data.Data.IsNull() {
+		v, d := decodeDynamicValue(path.AtName("data"), data.Data.UnderlyingValue())
+		diags.Append(d...)
+		target.Data = v
+	}
+
+	return diags
+}
+
+func decodeInvoiceLine(path path.Path, data *InvoiceLine, invoiceLine **structs.InvoiceLine) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.InvoiceLine{}
+	if *invoiceLine == nil {
+		*invoiceLine = target
+	} else {
+		target = *invoiceLine
+	}
+
+	if f := data.Amount.ValueBigFloat(); f != nil {
+		target.Amount = json.Number(f.Text('f', -1))
+	}
+
+	return diags
+}
+
+func decodeOrderLine(path path.Path, data *OrderLine, orderLine **structs.OrderLine) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.OrderLine{}
+	if *orderLine == nil {
+		*orderLine = target
+	} else {
+		target = *orderLine
+	}
+
+	if !data.Options.IsNull() {
+		var v map[string]interface{}
+		if err := json.Unmarshal([]byte(data.Options.ValueString()), &v); err != nil {
+			diags.AddAttributeError(path.AtName("options"), "failed to unmarshal json", err.Error())
+		} else {
+			target.Options = v
+		}
+	}
+
+	return diags
+}
+
+// decodeDynamicValue converts the value of a types.Dynamic to bool, int64,
+// float64, string, []interface{} or map[string]interface{}
+func decodeDynamicValue(path path.Path, value attr.Value) (interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value == nil || value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case types.Dynamic:
+		return decodeDynamicValue(path, v.UnderlyingValue())
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.String:
+		return v.ValueString(), nil
+	case types.Int64:
+		return v.ValueInt64(), nil
+	case types.Float64:
+		return v.ValueFloat64(), nil
+	case types.Number:
+		return decodeDynamicNumber(path, v.ValueBigFloat())
+	case types.List:
+		res := make([]interface{}, len(v.Elements()))
+		for i, e := range v.Elements() {
+			elem, d := decodeDynamicValue(path.AtListIndex(i), e)
+			diags.Append(d...)
+			res[i] = elem
+		}
+		return res, diags
+	case types.Set:
+		res := make([]interface{}, len(v.Elements()))
+		for i, e := range v.Elements() {
+			elem, d := decodeDynamicValue(path.AtSetValue(e), e)
+			diags.Append(d...)
+			res[i] = elem
+		}
+		return res, diags
+	case types.Tuple:
+		res := make([]interface{}, len(v.Elements()))
+		for i, e := range v.Elements() {
+			elem, d := decodeDynamicValue(path.AtTupleIndex(i), e)
+			diags.Append(d...)
+			res[i] = elem
+		}
+		return res, diags
+	case types.Map:
+		res := make(map[string]interface{}, len(v.Elements()))
+		for k, e := range v.Elements() {
+			elem, d := decodeDynamicValue(path.AtMapKey(k), e)
+			diags.Append(d...)
+			res[k] = elem
+		}
+		return res, diags
+	case types.Object:
+		res := make(map[string]interface{}, len(v.Attributes()))
+		for k, e := range v.Attributes() {
+			elem, d := decodeDynamicValue(path.AtName(k), e)
+			diags.Append(d...)
+			res[k] = elem
+		}
+		return res, diags
+	}
+
+	diags.AddAttributeError(path, "unsupported dynamic value", fmt.Sprintf("%T cannot be converted to a Go value", value))
+	return nil, diags
+}
+
+// decodeDynamicNumber converts f to an int64 when it is an integer that fits in
+// one and to a float64 otherwise, the numbers that a float64 cannot represent
+// without losing precision are reported
+func decodeDynamicNumber(path path.Path, f *big.Float) (interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if i, accuracy := f.Int64(); accuracy == big.Exact {
+		return i, nil
+	}
+
+	// The float64 must give back f when it is parsed with the precision of f,
+	// e.g. 0.1 can be decoded but not 0.10000000000000000001
+	x, _ := f.Float64()
+	g, _, err := big.ParseFloat(strconv.FormatFloat(x, 'g', -1, 64), 10, f.Prec(), big.ToNearestEven)
+	if err != nil || g.Cmp(f) != 0 {
+		diags.AddAttributeError(path, "invalid number", fmt.Sprintf("%s cannot be converted to a float64 without losing precision", f.Text('g', -1)))
+		return nil, diags
+	}
+	return x, nil
+}
@@ -2,31 +2,51 @@
 Code generated by github-terraform-generator; DO NOT EDIT.
 Any modifications will be overwritten
 */
+// Generator: models
 
 package tests
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	structs "github.com/Lenstra/terraform-plugin-generator/tests/structs"
+	jsontypes "github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	attr "github.com/hashicorp/terraform-plugin-framework/attr"
 	diag "github.com/hashicorp/terraform-plugin-framework/diag"
+	path "github.com/hashicorp/terraform-plugin-framework/path"
 	types "github.com/hashicorp/terraform-plugin-framework/types"
+	"math"
+	"math/big"
+	"reflect"
 )
 
 type Setter interface {
 	Set(context.Context, interface{}) diag.Diagnostics
 }
 
-func Set[Model *structs.Coffee | *structs.Config | *structs.Ingredient](ctx context.Context, setter Setter, obj Model) diag.Diagnostics {
+func Set[Model *structs.Account | *structs.Coffee | *structs.Config | *structs.Event | *structs.Ingredient | *structs.Invoice | *structs.Limits | *structs.Order | *ListDataSource[structs.Coffee]](ctx context.Context, setter Setter, obj Model) diag.Diagnostics {
 	var diags diag.Diagnostics
 	var converted interface{}
 	switch o := any(obj).(type) {
+	case *structs.Account:
+		converted, diags = EncodeAccount(o)
 	case *structs.Coffee:
 		converted, diags = EncodeCoffee(o)
 	case *structs.Config:
 		converted, diags = EncodeConfig(o)
+	case *structs.Event:
+		converted, diags = EncodeEvent(o)
 	case *structs.Ingredient:
 		converted, diags = EncodeIngredient(o)
+	case *structs.Invoice:
+		converted, diags = EncodeInvoice(o)
+	case *structs.Limits:
+		converted, diags = EncodeLimits(o)
+	case *structs.Order:
+		converted, diags = EncodeOrder(o)
+	case *ListDataSource[structs.Coffee]:
+		converted, diags = EncodeCoffees(o)
 	default:
 		diags.AddError("unsupported object type", fmt.Sprintf("%T is not supported in %s.Set(). Please report this issue to the provider developers.", obj, "tests"))
 		return diags
@@ -35,7 +55,36 @@ func Set[Model *structs.Coffee | *structs.Config | *structs.Ingredient](ctx cont
 	return diags
 }
 
+func EncodeAccount(account *structs.Account) (*Account, diag.Diagnostics) {
+	return encodeAccount(path.Empty(), account)
+}
+
+func encodeAccount(path path.Path, account *structs.Account) (*Account, diag.Diagnostics) {
+	if account == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Account{}
+	res.Email = types.StringValue(account.Email)
+	res.Name = types.StringValue(account.Metadata.Name)
+	if account.Metadata.Audit != nil {
+		res.CreatedBy = types.StringValue(account.Metadata.Audit.CreatedBy)
+	}
+	if account.Metadata.Labels != nil {
+		res.Labels = map[string]types.String{}
+		for k, v := range account.Metadata.Labels {
+			res.Labels[k] = types.StringValue(v)
+		}
+	}
+	return &res, diags
+}
+
 func EncodeCoffee(coffee *structs.Coffee) (*Coffee, diag.Diagnostics) {
+	return encodeCoffee(path.Empty(), coffee)
+}
+
+func encodeCoffee(path path.Path, coffee *structs.Coffee) (*Coffee, diag.Diagnostics) {
 	if coffee == nil {
 		return nil, nil
 	}
@@ -51,7 +100,7 @@ func EncodeCoffee(coffee *structs.Coffee) (*Coffee, diag.Diagnostics) {
 		res.Ingredients = make([]*Ingredient, len(coffee.Ingredients))
 		for i, attr := range coffee.Ingredients {
 			{
-				data, d := EncodeIngredient(&attr)
+				data, d := encodeIngredient(path.AtName("ingredients").AtListIndex(i), &attr)
 				diags.Append(d...)
 				if diags.HasError() {
 					return nil, diags
@@ -63,7 +112,7 @@ func EncodeCoffee(coffee *structs.Coffee) (*Coffee, diag.Diagnostics) {
 		}
 	}
 	{
-		data, d := encodeCustomer(coffee.Customer)
+		data, d := encodeCustomer(path.AtName("customer"), coffee.Customer)
 		diags.Append(d...)
 		if diags.HasError() {
 			return nil, diags
@@ -72,10 +121,65 @@ func EncodeCoffee(coffee *structs.Coffee) (*Coffee, diag.Diagnostics) {
 			res.Customer = data
 		}
 	}
+	if coffee.Tags != nil {
+		res.Tags = make([]types.String, len(coffee.Tags))
+		for i, attr := range coffee.Tags {
+			res.Tags[i] = types.StringValue(attr)
+		}
+	}
+	if coffee.Baristas != nil {
+		res.Baristas = make([]*Customer, len(coffee.Baristas))
+		for i, attr := range coffee.Baristas {
+			{
+				data, d := encodeCustomer(path.AtName("baristas"), &attr)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Baristas[i] = data
+				}
+			}
+		}
+	}
+	if coffee.Suppliers != nil {
+		res.Suppliers = map[string]*Customer{}
+		for k, v := range coffee.Suppliers {
+			{
+				data, d := encodeCustomer(path.AtName("suppliers").AtMapKey(k), &v)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Suppliers[k] = data
+				}
+			}
+		}
+	}
+	if coffee.Origins != nil {
+		res.Origins = map[string]*Ingredient{}
+		for k, v := range coffee.Origins {
+			{
+				data, d := encodeIngredient(path.AtName("origins").AtMapKey(k), v)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Origins[k] = data
+				}
+			}
+		}
+	}
 	return &res, diags
 }
 
 func EncodeConfig(config *structs.Config) (*Config, diag.Diagnostics) {
+	return encodeConfig(path.Empty(), config)
+}
+
+func encodeConfig(path path.Path, config *structs.Config) (*Config, diag.Diagnostics) {
 	if config == nil {
 		return nil, nil
 	}
@@ -83,13 +187,54 @@ func EncodeConfig(config *structs.Config) (*Config, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	res := Config{}
 	res.Host = types.StringValue(config.Host)
+	res.Password = types.StringValue(config.Password)
+	res.Token = types.StringValue(config.Token)
 	res.Bool = types.BoolValue(config.PromotedBool.Bool)
 	res.Int = types.Int64Value(int64(config.PromotedInt.Int))
 	res.String = types.StringValue(config.PromotedString.String)
 	return &res, diags
 }
 
+func EncodeEvent(event *structs.Event) (*Event, diag.Diagnostics) {
+	return encodeEvent(path.Empty(), event)
+}
+
+func encodeEvent(path path.Path, event *structs.Event) (*Event, diag.Diagnostics) {
+	if event == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Event{}
+	res.Name = types.StringValue(event.Name)
+	if event.Data != nil {
+		v, d := encodeDynamic(path.AtName("data"), event.Data)
+		diags.Append(d...)
+		res.Data = v
+	}
+	if event.Details != nil {
+		v, d := encodeDynamic(path.AtName("details"), event.Details)
+		diags.Append(d...)
+		res.Details = v
+	}
+	{
+		data, d := encodeSource(path.AtName("source"), event.Source)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		if data != nil {
+			res.Source = data
+		}
+	}
+	return &res, diags
+}
+
 func EncodeIngredient(ingredient *structs.Ingredient) (*Ingredient, diag.Diagnostics) {
+	return encodeIngredient(path.Empty(), ingredient)
+}
+
+func encodeIngredient(path path.Path, ingredient *structs.Ingredient) (*Ingredient, diag.Diagnostics) {
 	if ingredient == nil {
 		return nil, nil
 	}
@@ -102,7 +247,137 @@ func EncodeIngredient(ingredient *structs.Ingredient) (*Ingredient, diag.Diagnos
 	return &res, diags
 }
 
-func encodeCustomer(customer *structs.Customer) (*Customer, diag.Diagnostics) {
+func EncodeInvoice(invoice *structs.Invoice) (*Invoice, diag.Diagnostics) {
+	return encodeInvoice(path.Empty(), invoice)
+}
+
+func encodeInvoice(path path.Path, invoice *structs.Invoice) (*Invoice, diag.Diagnostics) {
+	if invoice == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Invoice{}
+	if invoice.Total != nil {
+		res.Total = types.NumberValue(new(big.Float).SetInt(invoice.Total))
+	}
+	if invoice.Rate != nil {
+		res.Rate = types.NumberValue(new(big.Float).Copy(invoice.Rate))
+	}
+	res.Balance = types.NumberValue(new(big.Float).SetInt(&invoice.Balance))
+	if invoice.Amount != "" {
+		f, _, err := big.ParseFloat(string(invoice.Amount), 10, 512, big.ToNearestEven)
+		if err != nil {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("amount"), "invalid number", err.Error()))
+		} else {
+			res.Amount = types.NumberValue(f)
+		}
+	}
+	res.Sequence = types.NumberValue(new(big.Float).SetUint64(invoice.Sequence))
+	if invoice.Previous != nil {
+		res.Previous = types.NumberValue(new(big.Float).SetUint64(*invoice.Previous))
+	}
+	if invoice.Lines != nil {
+		res.Lines = make([]*InvoiceLine, len(invoice.Lines))
+		for i, attr := range invoice.Lines {
+			{
+				data, d := encodeInvoiceLine(path.AtName("lines").AtListIndex(i), &attr)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Lines[i] = data
+				}
+			}
+		}
+	}
+	return &res, diags
+}
+
+func EncodeLimits(limits *structs.Limits) (*Limits, diag.Diagnostics) {
+	return encodeLimits(path.Empty(), limits)
+}
+
+func encodeLimits(path path.Path, limits *structs.Limits) (*Limits, diag.Diagnostics) {
+	if limits == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Limits{}
+	res.Retries = types.Int64Value(int64(limits.Retries))
+	if limits.Priority != nil {
+		res.Priority = types.Int64Value(int64(*limits.Priority))
+	}
+	res.Port = types.Int64Value(int64(limits.Port))
+	res.Count = types.Int64Value(int64(limits.Count))
+	res.Level = types.Int64Value(int64(limits.Level))
+	res.Severity = types.Int64Value(int64(limits.Severity))
+	if limits.Timeout != nil {
+		res.Timeout = types.Int64Value(int64(*limits.Timeout))
+	}
+	res.Used = types.Int64Value(int64(limits.Used))
+	return &res, diags
+}
+
+func EncodeOrder(order *structs.Order) (*Order, diag.Diagnostics) {
+	return encodeOrder(path.Empty(), order)
+}
+
+func encodeOrder(path path.Path, order *structs.Order) (*Order, diag.Diagnostics) {
+	if order == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Order{}
+	if order.Options != nil {
+		data, err := json.Marshal(order.Options)
+		if err != nil {
+			diags.AddAttributeError(path.AtName("options"), "failed to marshal json", err.Error())
+		} else {
+			res.Options = jsontypes.NewNormalizedValue(string(data))
+		}
+	}
+	if order.Steps != nil {
+		data, err := json.Marshal(order.Steps)
+		if err != nil {
+			diags.AddAttributeError(path.AtName("steps"), "failed to marshal json", err.Error())
+		} else {
+			res.Steps = jsontypes.NewNormalizedValue(string(data))
+		}
+	}
+	if order.Raw != nil {
+		res.Raw = jsontypes.NewNormalizedValue(string(order.Raw))
+	}
+	if order.Payload != nil {
+		data, err := json.Marshal(order.Payload)
+		if err != nil {
+			diags.AddAttributeError(path.AtName("payload"), "failed to marshal json", err.Error())
+		} else {
+			res.Payload = jsontypes.NewNormalizedValue(string(data))
+		}
+	}
+	if order.Lines != nil {
+		res.Lines = make([]*OrderLine, len(order.Lines))
+		for i, attr := range order.Lines {
+			{
+				data, d := encodeOrderLine(path.AtName("lines").AtListIndex(i), &attr)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Lines[i] = data
+				}
+			}
+		}
+	}
+	return &res, diags
+}
+
+func encodeCustomer(path path.Path, customer *structs.Customer) (*Customer, diag.Diagnostics) {
 	if customer == nil {
 		return nil, nil
 	}
@@ -113,3 +388,189 @@ func encodeCustomer(customer *structs.Customer) (*Customer, diag.Diagnostics) {
 	res.Name = types.StringValue(customer.Name)
 	return &res, diags
 }
+
+func encodeSource(path path.Path, source *structs.Source) (*Source, diag.Diagnostics) {
+	if source == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Source{}
+	if source.Data != nil {
+		v, d := encodeDynamic(path.AtName("data"), source.Data)
+		diags.Append(d...)
+		res.Data = v
+	}
+	return &res, diags
+}
+
+func encodeInvoiceLine(path path.Path, invoiceLine *structs.InvoiceLine) (*InvoiceLine, diag.Diagnostics) {
+	if invoiceLine == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := InvoiceLine{}
+	if invoiceLine.Amount != "" {
+		f, _, err := big.ParseFloat(string(invoiceLine.Amount), 10, 512, big.ToNearestEven)
+		if err != nil {
+			diags.Append(diag.NewAttributeErrorDiagnostic(path.AtName("amount"), "invalid number", err.Error()))
+		} else {
+			res.Amount = types.NumberValue(f)
+		}
+	}
+	return &res, diags
+}
+
+func encodeOrderLine(path path.Path, orderLine *structs.OrderLine) (*OrderLine, diag.Diagnostics) {
+	if orderLine == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := OrderLine{}
+	if orderLine.Options != nil {
+		data, err := json.Marshal(orderLine.Options)
+		if err != nil {
+			diags.AddAttributeError(path.AtName("options"), "failed to marshal json", err.Error())
+		} else {
+			res.Options = jsontypes.NewNormalizedValue(string(data))
+		}
+	}
+	return &res, diags
+}
+
+func EncodeCoffees(coffees *ListDataSource[structs.Coffee]) (*Coffees, diag.Diagnostics) {
+	return encodeCoffees(path.Empty(), coffees)
+}
+
+func encodeCoffees(path path.Path, coffees *ListDataSource[structs.Coffee]) (*Coffees, diag.Diagnostics) {
+	if coffees == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Coffees{}
+	if coffees.Items != nil {
+		res.Items = make([]*Coffee, len(coffees.Items))
+		for i, attr := range coffees.Items {
+			{
+				data, d := encodeCoffee(path.AtName("coffees").AtListIndex(i), &attr)
+				diags.Append(d...)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if data != nil {
+					res.Items[i] = data
+				}
+			}
+		}
+	}
+	filter, d := encodeCoffeesFilter(path.AtName("filter"), coffees.Filter)
+	diags.Append(d...)
+	res.Filter = filter
+	return &res, diags
+}
+
+func encodeCoffeesFilter(path path.Path, filter *structs.Coffee) (*CoffeesFilter, diag.Diagnostics) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := CoffeesFilter{}
+	res.Name = types.StringValue(filter.Name)
+	res.Teaser = types.StringValue(filter.Teaser)
+	return &res, diags
+}
+
+// encodeDynamic converts value to a types.Dynamic, see encodeDynamicValue
+func encodeDynamic(path path.Path, value interface{}) (types.Dynamic, diag.Diagnostics) {
+	v, diags := encodeDynamicValue(path, value)
+	if diags.HasError() {
+		return types.DynamicNull(), diags
+	}
+	if d, ok := v.(types.Dynamic); ok {
+		return d, diags
+	}
+	return types.DynamicValue(v), diags
+}
+
+// encodeDynamicValue converts value to an attr.Value, the slices and the arrays
+// are converted to tuples and the maps to objects like in the Terraform
+// configuration, nil is converted to a null types.Dynamic
+func encodeDynamicValue(path path.Path, value interface{}) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return types.DynamicNull(), nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Invalid:
+		return types.DynamicNull(), nil
+	case reflect.Bool:
+		return types.BoolValue(v.Bool()), nil
+	case reflect.String:
+		return types.StringValue(v.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return types.NumberValue(new(big.Float).SetInt64(v.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return types.NumberValue(new(big.Float).SetUint64(v.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			diags.AddAttributeError(path, "unsupported dynamic value", fmt.Sprintf("%v cannot be converted to a number", f))
+			return nil, diags
+		}
+		return types.NumberValue(big.NewFloat(f)), nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return types.DynamicNull(), nil
+		}
+		elementTypes := make([]attr.Type, v.Len())
+		elements := make([]attr.Value, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elem, d := encodeDynamicValue(path.AtTupleIndex(i), v.Index(i).Interface())
+			diags.Append(d...)
+			if d.HasError() {
+				return nil, diags
+			}
+			elementTypes[i] = elem.Type(context.Background())
+			elements[i] = elem
+		}
+		res, d := types.TupleValue(elementTypes, elements)
+		diags.Append(d...)
+		return res, diags
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			diags.AddAttributeError(path, "unsupported dynamic value", fmt.Sprintf("%T cannot be converted to an object, its keys must be strings", value))
+			return nil, diags
+		}
+		if v.IsNil() {
+			return types.DynamicNull(), nil
+		}
+		attributeTypes := map[string]attr.Type{}
+		attributes := map[string]attr.Value{}
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			elem, d := encodeDynamicValue(path.AtName(key), iter.Value().Interface())
+			diags.Append(d...)
+			if d.HasError() {
+				return nil, diags
+			}
+			attributeTypes[key] = elem.Type(context.Background())
+			attributes[key] = elem
+		}
+		res, d := types.ObjectValue(attributeTypes, attributes)
+		diags.Append(d...)
+		return res, diags
+	}
+
+	diags.AddAttributeError(path, "unsupported dynamic value", fmt.Sprintf("%T cannot be converted to a dynamic value", value))
+	return nil, diags
+}
@@ -2,26 +2,50 @@
 Code generated by github-terraform-generator; DO NOT EDIT.
 Any modifications will be overwritten
 */
+// Generator: models
 
 package tests
 
-import types "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	jsontypes "github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Account struct {
+	Email     types.String            `tfsdk:"email"`
+	Name      types.String            `tfsdk:"name"`
+	CreatedBy types.String            `tfsdk:"created_by"`
+	Labels    map[string]types.String `tfsdk:"labels"`
+}
 
 type Coffee struct {
-	ID          types.Int64   `tfsdk:"id"`
-	Name        types.String  `tfsdk:"name"`
-	Teaser      types.String  `tfsdk:"teaser"`
-	Description types.String  `tfsdk:"description"`
-	Image       types.String  `tfsdk:"image"`
-	Ingredients []*Ingredient `tfsdk:"ingredients"`
-	Customer    *Customer     `tfsdk:"customer"`
+	ID          types.Int64            `tfsdk:"id"`
+	Name        types.String           `tfsdk:"name"`
+	Teaser      types.String           `tfsdk:"teaser"`
+	Description types.String           `tfsdk:"description"`
+	Image       types.String           `tfsdk:"image"`
+	Ingredients []*Ingredient          `tfsdk:"ingredients"`
+	Customer    *Customer              `tfsdk:"customer"`
+	Tags        []types.String         `tfsdk:"tags"`
+	Baristas    []*Customer            `tfsdk:"baristas"`
+	Suppliers   map[string]*Customer   `tfsdk:"suppliers"`
+	Origins     map[string]*Ingredient `tfsdk:"origins"`
 }
 
 type Config struct {
-	Host   types.String `tfsdk:"host"`
-	Bool   types.Bool   `tfsdk:"bool"`
-	Int    types.Int64  `tfsdk:"int"`
-	String types.String `tfsdk:"string"`
+	Host     types.String `tfsdk:"host"`
+	Password types.String `tfsdk:"password"`
+	Token    types.String `tfsdk:"token"`
+	Bool     types.Bool   `tfsdk:"bool"`
+	Int      types.Int64  `tfsdk:"int"`
+	String   types.String `tfsdk:"string"`
+}
+
+type Event struct {
+	Name    types.String  `tfsdk:"name"`
+	Data    types.Dynamic `tfsdk:"data"`
+	Details types.Dynamic `tfsdk:"details"`
+	Source  *Source       `tfsdk:"source"`
 }
 
 type Ingredient struct {
@@ -30,7 +54,64 @@ type Ingredient struct {
 	Float64 types.Float64 `tfsdk:"float64"`
 }
 
+type Invoice struct {
+	Total    types.Number   `tfsdk:"total"`
+	Rate     types.Number   `tfsdk:"rate"`
+	Balance  types.Number   `tfsdk:"balance"`
+	Amount   types.Number   `tfsdk:"amount"`
+	Sequence types.Number   `tfsdk:"sequence"`
+	Previous types.Number   `tfsdk:"previous"`
+	Lines    []*InvoiceLine `tfsdk:"lines"`
+}
+
+type Limits struct {
+	Retries  types.Int64 `tfsdk:"retries"`
+	Priority types.Int64 `tfsdk:"priority"`
+	Port     types.Int64 `tfsdk:"port"`
+	Count    types.Int64 `tfsdk:"count"`
+	Level    types.Int64 `tfsdk:"level"`
+	Severity types.Int64 `tfsdk:"severity"`
+	Timeout  types.Int64 `tfsdk:"timeout"`
+	Used     types.Int64 `tfsdk:"used"`
+}
+
+type Order struct {
+	Options jsontypes.Normalized `tfsdk:"options"`
+	Steps   jsontypes.Normalized `tfsdk:"steps"`
+	Raw     jsontypes.Normalized `tfsdk:"raw"`
+	Payload jsontypes.Normalized `tfsdk:"payload"`
+	Lines   []*OrderLine         `tfsdk:"lines"`
+}
+
 type Customer struct {
 	ID   types.Int64  `tfsdk:"id"`
 	Name types.String `tfsdk:"name"`
 }
+
+type Source struct {
+	Data types.Dynamic `tfsdk:"data"`
+}
+
+type InvoiceLine struct {
+	Amount types.Number `tfsdk:"amount"`
+}
+
+type OrderLine struct {
+	Options jsontypes.Normalized `tfsdk:"options"`
+}
+
+// ListDataSource is the Go representation of the list data sources
+type ListDataSource[T any] struct {
+	Items  []T
+	Filter *T
+}
+
+type Coffees struct {
+	Items  []*Coffee      `tfsdk:"coffees"`
+	Filter *CoffeesFilter `tfsdk:"filter"`
+}
+
+type CoffeesFilter struct {
+	Name   types.String `tfsdk:"name"`
+	Teaser types.String `tfsdk:"teaser"`
+}
@@ -1,10 +1,22 @@
 package tests
 
 import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
 	"testing"
 
 	structs "github.com/Lenstra/terraform-plugin-generator/tests/structs"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/require"
 )
 
@@ -19,3 +31,348 @@ func TestEncoding(t *testing.T) {
 
 	require.Equal(t, config, roundTrip)
 }
+
+func TestEncodingSet(t *testing.T) {
+	coffee := &structs.Coffee{
+		Name: "espresso",
+		Tags: []string{"hot", "strong"},
+		Baristas: []structs.Customer{
+			{ID: 1, Name: "alice"},
+		},
+	}
+	data, diags := EncodeCoffee(coffee)
+	require.False(t, diags.HasError())
+
+	var roundTrip *structs.Coffee
+	diags = decodeCoffee(path.Empty(), data, &roundTrip)
+	require.False(t, diags.HasError())
+
+	require.Equal(t, coffee, roundTrip)
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	coffee := &structs.Coffee{
+		ID:   1,
+		Name: "espresso",
+		Suppliers: map[string]structs.Customer{
+			"main": {ID: 2, Name: "bob"},
+		},
+		Origins: map[string]*structs.Ingredient{
+			"beans": {ID: 3, Float32: 1.5},
+		},
+	}
+
+	s := coffeeSchema()
+	state := &tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+	diags := Set(ctx, state, coffee)
+	require.False(t, diags.HasError(), diags)
+
+	var roundTrip *structs.Coffee
+	diags = Decode(ctx, state, &roundTrip)
+	require.False(t, diags.HasError(), diags)
+
+	require.Equal(t, coffee, roundTrip)
+}
+
+func TestDecodingDeprecatedState(t *testing.T) {
+	ctx := context.Background()
+	coffee := &structs.Coffee{
+		Name:        "espresso",
+		Description: "strong",
+		Customer:    &structs.Customer{ID: 1, Name: "alice"},
+	}
+
+	s := coffeeSchema()
+	state := &tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+	diags := Set(ctx, state, coffee)
+	require.False(t, diags.HasError(), diags)
+
+	// The framework warns about the deprecated attributes set in the
+	// configuration, reading them from the state must not
+	var roundTrip *structs.Coffee
+	diags = Decode(ctx, state, &roundTrip)
+	require.Empty(t, diags)
+	require.Equal(t, coffee, roundTrip)
+}
+
+func TestEncodingPromoted(t *testing.T) {
+	account := &structs.Account{
+		Email: "alice@example.com",
+		Metadata: structs.Metadata{
+			Audit: &structs.Audit{CreatedBy: "bob"},
+			Name:  "alice",
+		},
+	}
+	account.Labels = map[string]string{"team": "coffee"}
+
+	data, diags := EncodeAccount(account)
+	require.False(t, diags.HasError())
+	require.Equal(t, "bob", data.CreatedBy.ValueString())
+
+	var roundTrip *structs.Account
+	diags = decodeAccount(path.Empty(), data, &roundTrip)
+	require.False(t, diags.HasError())
+
+	require.Equal(t, account, roundTrip)
+
+	// The promoted pointers are allocated when decoding
+	data, diags = EncodeAccount(&structs.Account{})
+	require.False(t, diags.HasError())
+	require.True(t, data.CreatedBy.IsNull())
+
+	roundTrip = nil
+	diags = decodeAccount(path.Empty(), data, &roundTrip)
+	require.False(t, diags.HasError())
+	require.NotNil(t, roundTrip.Audit)
+}
+
+func TestEncodingListDataSource(t *testing.T) {
+	coffees := &ListDataSource[structs.Coffee]{
+		Items: []structs.Coffee{
+			{ID: 1, Name: "espresso"},
+			{ID: 2, Name: "latte", Teaser: "mild"},
+		},
+		Filter: &structs.Coffee{Teaser: "mild"},
+	}
+	data, diags := EncodeCoffees(coffees)
+	require.False(t, diags.HasError())
+	require.Len(t, data.Items, 2)
+	require.Equal(t, "mild", data.Filter.Teaser.ValueString())
+
+	var roundTrip *ListDataSource[structs.Coffee]
+	diags = decodeCoffees(path.Empty(), data, &roundTrip)
+	require.False(t, diags.HasError())
+
+	require.Equal(t, coffees, roundTrip)
+}
+
+func TestEncodingJSON(t *testing.T) {
+	order := &structs.Order{
+		Options: map[string]interface{}{"sugar": true, "size": "large"},
+		Steps:   []interface{}{"grind", float64(18)},
+		Raw:     json.RawMessage(`{"b":1,"a":2}`),
+		Payload: structs.Payload{"id": float64(1)},
+	}
+	data, diags := EncodeOrder(order)
+	require.False(t, diags.HasError(), diags)
+
+	var roundTrip *structs.Order
+	diags = decodeOrder(path.Empty(), data, &roundTrip)
+	require.False(t, diags.HasError(), diags)
+
+	require.Equal(t, order, roundTrip)
+
+	// Reordering the keys or changing the whitespaces does not produce a diff
+	reordered, diags := EncodeOrder(&structs.Order{Raw: json.RawMessage(`{ "a": 2, "b": 1 }`)})
+	require.False(t, diags.HasError(), diags)
+	equal, diags := data.Raw.StringSemanticEquals(context.Background(), reordered.Raw)
+	require.False(t, diags.HasError(), diags)
+	require.True(t, equal)
+
+	// The values that cannot be marshaled are reported as diagnostics
+	_, diags = EncodeOrder(&structs.Order{Options: map[string]interface{}{"f": func() {}}})
+	require.True(t, diags.HasError())
+	require.Equal(t, path.Root("options"), diags.Errors()[0].(diag.DiagnosticWithPath).Path())
+
+	// The path of the diagnostic is the one of the nested attribute
+	_, diags = EncodeOrder(&structs.Order{Lines: []structs.OrderLine{{}, {Options: map[string]interface{}{"f": func() {}}}}})
+	require.True(t, diags.HasError())
+	require.Equal(t, path.Root("lines").AtListIndex(1).AtName("options"), diags.Errors()[0].(diag.DiagnosticWithPath).Path())
+
+	// The values that cannot be unmarshaled are reported and the decoding
+	// goes on with the other attributes
+	data, diags = EncodeOrder(order)
+	require.False(t, diags.HasError(), diags)
+	data.Options = jsontypes.NewNormalizedValue("{")
+	data.Steps = jsontypes.NewNormalizedValue("[")
+	roundTrip = nil
+	diags = decodeOrder(path.Empty(), data, &roundTrip)
+	require.Len(t, diags.Errors(), 2)
+	require.Equal(t, path.Root("options"), diags.Errors()[0].(diag.DiagnosticWithPath).Path())
+	require.Equal(t, path.Root("steps"), diags.Errors()[1].(diag.DiagnosticWithPath).Path())
+	require.Equal(t, order.Payload, roundTrip.Payload)
+}
+
+func TestEncodingDynamic(t *testing.T) {
+	ctx := context.Background()
+	event := &structs.Event{
+		Name: "brewed",
+		Data: map[string]interface{}{
+			"cups":  int64(2),
+			"ratio": 0.5,
+			"sugar": true,
+			"steps": []interface{}{"grind", int64(18), nil},
+			"origin": map[string]interface{}{
+				"country": "Ethiopia",
+			},
+		},
+		Details: "espresso",
+		Source:  &structs.Source{Data: "grinder"},
+	}
+
+	s := eventSchema()
+	state := &tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+	diags := Set(ctx, state, event)
+	require.False(t, diags.HasError(), diags)
+
+	var roundTrip *structs.Event
+	diags = Decode(ctx, state, &roundTrip)
+	require.False(t, diags.HasError(), diags)
+
+	require.Equal(t, event, roundTrip)
+
+	// The values that cannot be converted are reported as diagnostics
+	_, diags = EncodeEvent(&structs.Event{Data: []interface{}{func() {}}})
+	require.True(t, diags.HasError())
+	require.Equal(t, path.Root("data").AtTupleIndex(0), diags.Errors()[0].(diag.DiagnosticWithPath).Path())
+
+	_, diags = EncodeEvent(&structs.Event{Source: &structs.Source{Data: []interface{}{func() {}}}})
+	require.True(t, diags.HasError())
+	require.Equal(t, path.Root("source").AtName("data").AtTupleIndex(0), diags.Errors()[0].(diag.DiagnosticWithPath).Path())
+
+	// The integers are decoded to int64 when they fit and the numbers that a
+	// float64 cannot represent are reported instead of being rounded
+	decodeNumber := func(number string) (interface{}, diag.Diagnostics) {
+		f, _, err := big.ParseFloat(number, 10, 512, big.ToNearestEven)
+		require.NoError(t, err)
+		var roundTrip *structs.Event
+		diags := decodeEvent(path.Empty(), &Event{Data: types.DynamicValue(types.NumberValue(f))}, &roundTrip)
+		if roundTrip == nil {
+			return nil, diags
+		}
+		return roundTrip.Data, diags
+	}
+	for number, expected := range map[string]interface{}{
+		"9007199254740993":     int64(9007199254740993),
+		"-9223372036854775808": int64(math.MinInt64),
+		"0.1":                  0.1,
+		"1e300":                1e300,
+	} {
+		value, diags := decodeNumber(number)
+		require.False(t, diags.HasError(), diags)
+		require.Equal(t, expected, value, number)
+	}
+	for _, number := range []string{"9223372036854775809", "0.10000000000000000001"} {
+		_, diags := decodeNumber(number)
+		require.True(t, diags.HasError(), number)
+		require.Equal(t, path.Root("data"), diags.Errors()[0].(diag.DiagnosticWithPath).Path())
+		require.Contains(t, diags.Errors()[0].Detail(), "cannot be converted to a float64 without losing precision")
+	}
+}
+
+func TestEncodingNumber(t *testing.T) {
+	ctx := context.Background()
+	total, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	previous := uint64(math.MaxUint64 - 1)
+	invoice := &structs.Invoice{
+		Total:    total,
+		Rate:     big.NewFloat(0.125),
+		Amount:   "12345678901234567890.5",
+		Sequence: math.MaxUint64,
+		Previous: &previous,
+	}
+	invoice.Balance.SetInt64(-42)
+
+	s := invoiceSchema()
+	state := &tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+	diags := Set(ctx, state, invoice)
+	require.False(t, diags.HasError(), diags)
+
+	var roundTrip *structs.Invoice
+	diags = Decode(ctx, state, &roundTrip)
+	require.False(t, diags.HasError(), diags)
+
+	require.Equal(t, 0, invoice.Total.Cmp(roundTrip.Total))
+	require.Equal(t, 0, invoice.Rate.Cmp(roundTrip.Rate))
+	require.Equal(t, 0, invoice.Balance.Cmp(&roundTrip.Balance))
+	require.Equal(t, invoice.Amount, roundTrip.Amount)
+	require.Equal(t, invoice.Sequence, roundTrip.Sequence)
+	require.Equal(t, invoice.Previous, roundTrip.Previous)
+
+	// The values that do not fit in the Go types are reported as diagnostics
+	data := &Invoice{
+		Total:    types.NumberValue(big.NewFloat(1.5)),
+		Sequence: types.NumberValue(big.NewFloat(-1)),
+	}
+	roundTrip = nil
+	diags = decodeInvoice(path.Empty(), data, &roundTrip)
+	require.Len(t, diags.Errors(), 2)
+	require.Equal(t, path.Empty().AtName("total"), diags.Errors()[0].(diag.DiagnosticWithPath).Path())
+	require.Equal(t, path.Empty().AtName("sequence"), diags.Errors()[1].(diag.DiagnosticWithPath).Path())
+	require.Contains(t, diags.Errors()[1].Detail(), "-1 does not fit in a uint64")
+
+	_, diags = EncodeInvoice(&structs.Invoice{Amount: "twelve"})
+	require.True(t, diags.HasError())
+	require.Equal(t, path.Root("amount"), diags.Errors()[0].(diag.DiagnosticWithPath).Path())
+
+	_, diags = EncodeInvoice(&structs.Invoice{Lines: []structs.InvoiceLine{{Amount: "1"}, {Amount: "twelve"}}})
+	require.True(t, diags.HasError())
+	require.Equal(t, path.Root("lines").AtListIndex(1).AtName("amount"), diags.Errors()[0].(diag.DiagnosticWithPath).Path())
+}
+
+func TestDecodingIntegerBounds(t *testing.T) {
+	priority := int16(3)
+	timeout := structs.Threshold(30)
+	limits := &structs.Limits{Retries: -128, Priority: &priority, Port: 65535, Count: 7, Level: 2, Severity: -1, Timeout: &timeout}
+	data, diags := EncodeLimits(limits)
+	require.False(t, diags.HasError(), diags)
+
+	var roundTrip *structs.Limits
+	diags = decodeLimits(path.Empty(), data, &roundTrip)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, limits, roundTrip)
+
+	// The values that do not fit in the Go types are not truncated
+	data = &Limits{
+		Retries:  types.Int64Value(300),
+		Priority: types.Int64Value(40000),
+		Port:     types.Int64Value(-1),
+		Count:    types.Int64Value(-1),
+		Severity: types.Int64Value(128),
+	}
+	roundTrip = nil
+	diags = decodeLimits(path.Empty(), data, &roundTrip)
+	require.Len(t, diags.Errors(), 5)
+	for i, name := range []string{"retries", "priority", "port", "count", "severity"} {
+		require.Equal(t, path.Empty().AtName(name), diags.Errors()[i].(diag.DiagnosticWithPath).Path())
+	}
+	require.Equal(t, "300 does not fit in an int8", diags.Errors()[0].Detail())
+	require.Equal(t, &structs.Limits{}, roundTrip)
+
+	// They are also rejected at plan time, the validators of the tag are
+	// added to the ones given by the getter
+	ctx := context.Background()
+	attribute := limitsSchema().Attributes["retries"].(schema.Int64Attribute)
+	require.Len(t, attribute.Validators, 2)
+	req := validator.Int64Request{Path: path.Root("retries"), ConfigValue: types.Int64Value(300)}
+	resp := &validator.Int64Response{}
+	for _, v := range attribute.Validators {
+		v.ValidateInt64(ctx, req, resp)
+	}
+	require.True(t, resp.Diagnostics.HasError())
+
+	// The values of the computed attributes are not given by the user
+	require.Empty(t, limitsSchema().Attributes["used"].(schema.Int64Attribute).Validators)
+}
+
+func TestSchemaPlanModifiers(t *testing.T) {
+	// The plan modifiers of the tag are added to the ones given by the
+	// FieldInformationGetter
+	attribute := coffeeSchema().Attributes["name"].(schema.StringAttribute)
+	require.Len(t, attribute.PlanModifiers, 2)
+	require.Equal(t, stringplanmodifier.UseStateForUnknown().Description(context.Background()), attribute.PlanModifiers[0].Description(context.Background()))
+	require.Equal(t, stringplanmodifier.RequiresReplace().Description(context.Background()), attribute.PlanModifiers[1].Description(context.Background()))
+}
@@ -2,75 +2,242 @@
 Code generated by github-terraform-generator; DO NOT EDIT.
 Any modifications will be overwritten
 */
+// Generator: resource schema
 
 package tests
 
-import schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+import (
+	jsontypes "github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	float64validator "github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	int64validator "github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	setvalidator "github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	stringvalidator "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	attr "github.com/hashicorp/terraform-plugin-framework/attr"
+	path "github.com/hashicorp/terraform-plugin-framework/path"
+	schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	float64default "github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
+	int64planmodifier "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	objectdefault "github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
+	objectplanmodifier "github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	planmodifier "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	setdefault "github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	stringdefault "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	stringplanmodifier "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	validator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+	"regexp"
+)
 
-func coffeeSchema() schema.Schema {
+func accountSchema() schema.Schema {
 	return schema.Schema{
-		MarkdownDescription: "",
+		MarkdownDescription: "Account reaches its attributes through several levels of embedded structs.",
 		Attributes: map[string]schema.Attribute{
-			"id": schema.Int64Attribute{
-				Optional:   true,
+			"email": schema.StringAttribute{
+				Required:   true,
 				Default:    nil,
 				Validators: nil,
 			},
 			"name": schema.StringAttribute{
-				Required:   true,
+				Optional:   true,
 				Default:    nil,
 				Validators: nil,
 			},
-			"teaser": schema.StringAttribute{
-				Optional:   true,
+			"created_by": schema.StringAttribute{
+				Computed:   true,
 				Default:    nil,
 				Validators: nil,
 			},
+			"labels": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Default:     nil,
+				Validators:  nil,
+			},
+		},
+		Blocks: map[string]schema.Block{},
+	}
+}
+
+func coffeeSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Coffee is a drink served by the coffee shop.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "ID is the identifier of the coffee.",
+				Default:             nil,
+				Validators:          nil,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name is the name displayed on the menu.",
+				Default:             nil,
+				Validators: append([]validator.String(nil),
+					stringvalidator.LengthBetween(1, 64),
+				),
+				PlanModifiers: append([]planmodifier.String([]planmodifier.String{stringplanmodifier.UseStateForUnknown()}),
+					stringplanmodifier.RequiresReplace(),
+				),
+			},
+			"teaser": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How strong the coffee is, either mild or strong.",
+				Default:             nil,
+				Validators: append([]validator.String(nil),
+					stringvalidator.OneOf("mild", "strong"),
+				),
+			},
 			"description": schema.StringAttribute{
-				Optional:   true,
-				Default:    nil,
-				Validators: nil,
+				Optional:           true,
+				DeprecationMessage: "Use teaser instead, description will be removed.",
+				Default:            nil,
+				Validators:         nil,
 			},
 			"image": schema.StringAttribute{
 				Optional:   true,
-				Default:    nil,
+				Computed:   true,
+				Default:    stringdefault.StaticString("espresso.png"),
 				Validators: nil,
 			},
 			"ingredients": &schema.ListNestedAttribute{
-				Optional: true,
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.Int64Attribute{
-							Required:   true,
-							Default:    nil,
-							Validators: nil,
+							Required: true,
+							Default:  nil,
+							Validators: append([]validator.Int64(nil),
+								int64validator.AtLeast(1),
+							),
 						},
 						"float32": schema.Float64Attribute{
-							Optional:   true,
-							Default:    nil,
-							Validators: nil,
+							Optional: true,
+							Default:  nil,
+							Validators: append([]validator.Float64(nil),
+								float64validator.Between(0.0, 100.0),
+							),
 						},
 						"float64": schema.Float64Attribute{
 							Optional:   true,
-							Default:    nil,
+							Computed:   true,
+							Default:    float64default.StaticFloat64(1.5),
 							Validators: nil,
 						},
 					}},
 			},
 			"customer": &schema.SingleNestedAttribute{
-				Optional:   true,
-				Default:    nil,
+				Optional: true,
+				Computed: true,
+				Default: objectdefault.StaticValue(types.ObjectValueMust(map[string]attr.Type{
+					"id":   types.Int64Type,
+					"name": types.StringType,
+				}, map[string]attr.Value{
+					"id":   types.Int64Null(),
+					"name": types.StringValue("anonymous"),
+				})),
 				Validators: nil,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplaceIfConfigured(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"id": schema.Int64Attribute{
-						Optional:   true,
-						Default:    nil,
-						Validators: nil,
+						Optional:            true,
+						MarkdownDescription: "The identifier of the customer.",
+						Default:             nil,
+						Validators:          nil,
 					},
 					"name": schema.StringAttribute{
-						Optional:   true,
-						Default:    nil,
-						Validators: nil,
+						Optional:            true,
+						MarkdownDescription: "Name of the customer.",
+						DeprecationMessage:  "the API does not return the name of the customers anymore.",
+						Default:             nil,
+						Validators:          nil,
+					},
+				},
+			},
+			"tags": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{types.StringValue("hot")})),
+				Validators: append([]validator.Set(nil),
+					setvalidator.SizeAtMost(10),
+				),
+			},
+			"baristas": &schema.SetNestedAttribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "The identifier of the customer.",
+							Default:             nil,
+							Validators:          nil,
+						},
+						"name": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Name of the customer.",
+							DeprecationMessage:  "the API does not return the name of the customers anymore.",
+							Default:             nil,
+							Validators:          nil,
+						},
+					}},
+			},
+			"suppliers": &schema.MapNestedAttribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "The identifier of the customer.",
+							Default:             nil,
+							Validators:          nil,
+						},
+						"name": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Name of the customer.",
+							DeprecationMessage:  "the API does not return the name of the customers anymore.",
+							Default:             nil,
+							Validators:          nil,
+						},
+					},
+				},
+			},
+			"origins": &schema.MapNestedAttribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Required: true,
+							Default:  nil,
+							Validators: append([]validator.Int64(nil),
+								int64validator.AtLeast(1),
+							),
+						},
+						"float32": schema.Float64Attribute{
+							Optional: true,
+							Default:  nil,
+							Validators: append([]validator.Float64(nil),
+								float64validator.Between(0.0, 100.0),
+							),
+						},
+						"float64": schema.Float64Attribute{
+							Optional:   true,
+							Computed:   true,
+							Default:    float64default.StaticFloat64(1.5),
+							Validators: nil,
+						},
 					},
 				},
 			},
@@ -84,9 +251,27 @@ func configSchema() schema.Schema {
 		MarkdownDescription: "",
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Required:   true,
-				Default:    nil,
-				Validators: nil,
+				Required: true,
+				Default:  nil,
+				Validators: append([]validator.String(nil),
+					stringvalidator.RegexMatches(regexp.MustCompile("^[a-z0-9.-]+$"), "must match ^[a-z0-9.-]+$"),
+				),
+			},
+			"password": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Default:   nil,
+				Validators: append([]validator.String(nil),
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("token")),
+				),
+			},
+			"token": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Default:   nil,
+				Validators: append([]validator.String(nil),
+					stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("password"), path.MatchRelative().AtParent().AtName("token")),
+				),
 			},
 			"bool": schema.BoolAttribute{
 				Optional:   true,
@@ -108,24 +293,226 @@ func configSchema() schema.Schema {
 	}
 }
 
+func eventSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Event holds free-form values.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+			},
+			"data": schema.DynamicAttribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+			},
+			"details": schema.DynamicAttribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+			},
+			"source": &schema.SingleNestedAttribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+				Attributes: map[string]schema.Attribute{
+					"data": schema.DynamicAttribute{
+						Optional:   true,
+						Default:    nil,
+						Validators: nil,
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{},
+	}
+}
+
 func ingredientSchema() schema.Schema {
 	return schema.Schema{
 		MarkdownDescription: "",
+		DeprecationMessage:  "Ingredients are now managed by the coffee resource.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
-				Required:   true,
+				Required: true,
+				Default:  nil,
+				Validators: append([]validator.Int64(nil),
+					int64validator.AtLeast(1),
+				),
+			},
+			"float32": schema.Float64Attribute{
+				Optional: true,
+				Default:  nil,
+				Validators: append([]validator.Float64(nil),
+					float64validator.Between(0.0, 100.0),
+				),
+			},
+			"float64": schema.Float64Attribute{
+				Optional:   true,
+				Computed:   true,
+				Default:    float64default.StaticFloat64(1.5),
+				Validators: nil,
+			},
+		},
+		Blocks: map[string]schema.Block{},
+	}
+}
+
+func invoiceSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Invoice holds arbitrary-precision numbers.",
+		Attributes: map[string]schema.Attribute{
+			"total": schema.NumberAttribute{
+				Optional:   true,
 				Default:    nil,
 				Validators: nil,
 			},
-			"float32": schema.Float64Attribute{
+			"rate": schema.NumberAttribute{
 				Optional:   true,
 				Default:    nil,
 				Validators: nil,
 			},
-			"float64": schema.Float64Attribute{
+			"balance": schema.NumberAttribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+			},
+			"amount": schema.NumberAttribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+			},
+			"sequence": schema.NumberAttribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+			},
+			"previous": schema.NumberAttribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+			},
+			"lines": &schema.ListNestedAttribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"amount": schema.NumberAttribute{
+							Optional:   true,
+							Default:    nil,
+							Validators: nil,
+						},
+					}},
+			},
+		},
+		Blocks: map[string]schema.Block{},
+	}
+}
+
+func limitsSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Limits holds integers narrower than an int64.",
+		Attributes: map[string]schema.Attribute{
+			"retries": schema.Int64Attribute{
+				Optional: true,
+				Default:  nil,
+				Validators: append([]validator.Int64([]validator.Int64{int64validator.AtLeast(-100)}),
+					int64validator.Between(-128, 127),
+				),
+			},
+			"priority": schema.Int64Attribute{
+				Optional: true,
+				Default:  nil,
+				Validators: append([]validator.Int64(nil),
+					int64validator.Between(1, 32767),
+				),
+			},
+			"port": schema.Int64Attribute{
+				Optional: true,
+				Default:  nil,
+				Validators: append([]validator.Int64(nil),
+					int64validator.Between(0, 65535),
+				),
+			},
+			"count": schema.Int64Attribute{
+				Optional: true,
+				Default:  nil,
+				Validators: append([]validator.Int64(nil),
+					int64validator.AtLeast(0),
+				),
+			},
+			"level": schema.Int64Attribute{
+				Optional: true,
+				Default:  nil,
+				Validators: append([]validator.Int64(nil),
+					int64validator.OneOf(1, 2),
+				),
+			},
+			"severity": schema.Int64Attribute{
+				Optional: true,
+				Default:  nil,
+				Validators: append([]validator.Int64(nil),
+					int64validator.Between(-128, 127),
+				),
+			},
+			"timeout": schema.Int64Attribute{
+				Optional:   true,
+				Default:    nil,
+				Validators: nil,
+			},
+			"used": schema.Int64Attribute{
+				Computed:   true,
+				Default:    nil,
+				Validators: nil,
+			},
+		},
+		Blocks: map[string]schema.Block{},
+	}
+}
+
+func orderSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Order holds arbitrary JSON documents.",
+		Attributes: map[string]schema.Attribute{
+			"options": schema.StringAttribute{
+				Optional:   true,
+				CustomType: jsontypes.NormalizedType{},
+				Default:    nil,
+				Validators: nil,
+			},
+			"steps": schema.StringAttribute{
+				Optional:   true,
+				CustomType: jsontypes.NormalizedType{},
+				Default:    nil,
+				Validators: nil,
+			},
+			"raw": schema.StringAttribute{
+				Optional:   true,
+				CustomType: jsontypes.NormalizedType{},
+				Default:    nil,
+				Validators: nil,
+			},
+			"payload": schema.StringAttribute{
+				Optional:   true,
+				CustomType: jsontypes.NormalizedType{},
+				Default:    nil,
+				Validators: nil,
+			},
+			"lines": &schema.ListNestedAttribute{
 				Optional:   true,
 				Default:    nil,
 				Validators: nil,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"options": schema.StringAttribute{
+							Optional:   true,
+							CustomType: jsontypes.NormalizedType{},
+							Default:    nil,
+							Validators: nil,
+						},
+					}},
 			},
 		},
 		Blocks: map[string]schema.Block{},
@@ -1,10 +1,18 @@
 package structs
 
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+)
+
 type Config struct {
-	Host           string         `terraform:"host,required"`
+	Host           string         `terraform:"host,required,regex=^[a-z0-9.-]+$"`
 	PromotedBool   PromotedBool   `terraform:"-,promoted"`
 	PromotedInt    PromotedInt    `terraform:"-,promoted"`
 	PromotedString PromotedString `terraform:"-,promoted"`
+	Password       string         `terraform:"password,sensitive,conflicts_with=token"`
+	Token          string         `terraform:"token,sensitive,exactly_one_of=password|token"`
 }
 
 type PromotedBool struct {
@@ -19,23 +27,125 @@ type PromotedString struct {
 	String string `terraform:"string"`
 }
 
+// Coffee is a drink served by the coffee shop.
 type Coffee struct {
-	ID          int          `terraform:"id"`
-	Name        string       `terraform:"name,required"`
-	Teaser      string       `terraform:"teaser"`
-	Description string       `terraform:"description"`
-	Image       string       `terraform:"image"`
-	Ingredients []Ingredient `terraform:"ingredients"`
-	Customer    *Customer    `terraform:"customer"`
+	// ID is the identifier of the coffee.
+	ID int `terraform:"id,computed,use_state_for_unknown,lookup"`
+	// Name is the name displayed on the menu.
+	Name        string                 `terraform:"name,required,length=1..64,force_new,filterable"`
+	Teaser      string                 `terraform:"teaser,oneof=mild|strong,filterable,description=\"How strong the coffee is, either mild or strong.\""`
+	Description string                 `terraform:"description,deprecated=\"Use teaser instead, description will be removed.\""`
+	Image       string                 `terraform:"image,default=espresso.png"`
+	Ingredients []Ingredient           `terraform:"ingredients"`
+	Customer    *Customer              `terraform:"customer,requires_replace_if_configured,default={\"name\":\"anonymous\"}"`
+	Tags        []string               `terraform:"tags,set,max_items=10,default=[\"hot\"]"`
+	Baristas    []Customer             `terraform:"baristas,set"`
+	Suppliers   map[string]Customer    `terraform:"suppliers"`
+	Origins     map[string]*Ingredient `terraform:"origins"`
 }
 
 type Ingredient struct {
-	ID      int     `terraform:"id,required"`
-	Float32 float32 `terraform:"float32"`
-	Float64 float64 `terraform:"float64"`
+	ID      int     `terraform:"id,required,min=1"`
+	Float32 float32 `terraform:"float32,min=0,max=100"`
+	Float64 float64 `terraform:"float64,default=1.5"`
+}
+
+// DeprecationMessage deprecates the ingredient schema.
+func (Ingredient) DeprecationMessage() string {
+	return "Ingredients are now managed by the coffee resource."
 }
 
 type Customer struct {
-	ID   int64  `terraform:"id"`
+	ID int64 `terraform:"id"` // The identifier of the customer.
+	// Name of the customer.
+	//
+	// Deprecated: the API does not return the name of the customers anymore.
+	Name string `terraform:"name"`
+}
+
+// Account reaches its attributes through several levels of embedded structs.
+type Account struct {
+	Metadata
+	Email string `terraform:"email,required"`
+}
+
+type Metadata struct {
+	*Audit
+	labels
 	Name string `terraform:"name"`
 }
+
+type Audit struct {
+	CreatedBy string `terraform:"created_by,computed"`
+}
+
+type labels struct {
+	Labels map[string]string `terraform:"labels"`
+}
+
+// Brewing is rendered with the custom types of terraform-plugin-framework-timetypes.
+type Brewing struct {
+	StartedAt  time.Time       `terraform:"started_at,required"`
+	FinishedAt *time.Time      `terraform:"finished_at"`
+	Duration   time.Duration   `terraform:"duration,default=4m"`
+	Steps      []time.Duration `terraform:"steps"`
+	Notes      string          `terraform:"notes,default="`
+}
+
+// Order holds arbitrary JSON documents.
+type Order struct {
+	Options map[string]interface{} `terraform:"options"`
+	Steps   []interface{}          `terraform:"steps"`
+	Raw     json.RawMessage        `terraform:"raw"`
+	Payload Payload                `terraform:"payload"`
+	Lines   []OrderLine            `terraform:"lines"`
+}
+
+type Payload map[string]any
+
+type OrderLine struct {
+	Options map[string]interface{} `terraform:"options"`
+}
+
+// Event holds free-form values.
+type Event struct {
+	Name    string      `terraform:"name"`
+	Data    any         `terraform:"data"`
+	Details interface{} `terraform:"details"`
+	Source  *Source     `terraform:"source"`
+}
+
+type Source struct {
+	Data any `terraform:"data"`
+}
+
+// Invoice holds arbitrary-precision numbers.
+type Invoice struct {
+	Total    *big.Int      `terraform:"total"`
+	Rate     *big.Float    `terraform:"rate"`
+	Balance  big.Int       `terraform:"balance"`
+	Amount   json.Number   `terraform:"amount"`
+	Sequence uint64        `terraform:"sequence,number"`
+	Previous *uint64       `terraform:"previous,number"`
+	Lines    []InvoiceLine `terraform:"lines"`
+}
+
+type InvoiceLine struct {
+	Amount json.Number `terraform:"amount"`
+}
+
+// Limits holds integers narrower than an int64.
+type Limits struct {
+	Retries  int8       `terraform:"retries,lookup"`
+	Priority *int16     `terraform:"priority,min=1"`
+	Port     uint16     `terraform:"port"`
+	Count    uint       `terraform:"count"`
+	Level    int32      `terraform:"level,oneof=1|2"`
+	Severity Severity   `terraform:"severity"`
+	Timeout  *Threshold `terraform:"timeout"`
+	Used     int8       `terraform:"used,computed"`
+}
+
+type Severity int8
+
+type Threshold int64
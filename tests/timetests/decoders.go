@@ -0,0 +1,98 @@
+/*
+Code generated by github-terraform-generator; DO NOT EDIT.
+Any modifications will be overwritten
+*/
+// Generator: models
+
+package timetests
+
+import (
+	"context"
+	"fmt"
+	structs "github.com/Lenstra/terraform-plugin-generator/tests/structs"
+	diag "github.com/hashicorp/terraform-plugin-framework/diag"
+	path "github.com/hashicorp/terraform-plugin-framework/path"
+	"time"
+)
+
+type Getter interface {
+	Get(context.Context, interface{}) diag.Diagnostics
+}
+
+func Decode[Target **structs.Brewing](ctx context.Context, getter Getter, obj Target) diag.Diagnostics {
+	switch o := any(obj).(type) {
+	case **structs.Brewing:
+		return DecodeBrewing(ctx, getter, o)
+	default:
+		var diags diag.Diagnostics
+		diags.AddError("unsupported object type", fmt.Sprintf("%T is not supported in %s.Set(). Please report this issue to the provider developers.", obj, "timetests"))
+		return diags
+	}
+}
+
+func DecodeBrewing(ctx context.Context, getter Getter, brewing **structs.Brewing) diag.Diagnostics {
+	var data *Brewing
+	diags := getter.Get(ctx, &data)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(decodeBrewing(path.Empty(), data, brewing)...)
+	return diags
+}
+
+func decodeBrewing(path path.Path, data *Brewing, brewing **structs.Brewing) (diags diag.Diagnostics) {
+	if data == nil {
+		return nil
+	}
+
+	target := &structs.Brewing{}
+	if *brewing == nil {
+		*brewing = target
+	} else {
+		target = *brewing
+	}
+
+	if !data.StartedAt.IsNull() {
+		t, d := data.StartedAt.ValueRFC3339Time()
+		diags.Append(d...)
+		if !d.HasError() {
+			target.StartedAt = t
+		}
+	}
+
+	if !data.FinishedAt.IsNull() {
+		t, d := data.FinishedAt.ValueRFC3339Time()
+		diags.Append(d...)
+		if !d.HasError() {
+			target.FinishedAt = &t
+		}
+	}
+
+	if !data.Duration.IsNull() {
+		dur, d := data.Duration.ValueGoDuration()
+		diags.Append(d...)
+		if !d.HasError() {
+			target.Duration = dur
+		}
+	}
+
+	if data.Steps != nil {
+		target.Steps = make([]time.Duration, len(data.Steps))
+		for i, data := range data.Steps {
+			if !data.IsNull() {
+				dur, d := data.ValueGoDuration()
+				diags.Append(d...)
+				if !d.HasError() {
+					target.Steps[i] = dur
+				}
+			}
+		}
+	}
+
+	if !data.Notes.IsNull() {
+		target.Notes = data.Notes.ValueString()
+	}
+
+	return diags
+}
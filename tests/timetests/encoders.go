@@ -0,0 +1,59 @@
+/*
+Code generated by github-terraform-generator; DO NOT EDIT.
+Any modifications will be overwritten
+*/
+// Generator: models
+
+package timetests
+
+import (
+	"context"
+	"fmt"
+	structs "github.com/Lenstra/terraform-plugin-generator/tests/structs"
+	timetypes "github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	diag "github.com/hashicorp/terraform-plugin-framework/diag"
+	path "github.com/hashicorp/terraform-plugin-framework/path"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Setter interface {
+	Set(context.Context, interface{}) diag.Diagnostics
+}
+
+func Set[Model *structs.Brewing](ctx context.Context, setter Setter, obj Model) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var converted interface{}
+	switch o := any(obj).(type) {
+	case *structs.Brewing:
+		converted, diags = EncodeBrewing(o)
+	default:
+		diags.AddError("unsupported object type", fmt.Sprintf("%T is not supported in %s.Set(). Please report this issue to the provider developers.", obj, "timetests"))
+		return diags
+	}
+	diags.Append(setter.Set(ctx, converted)...)
+	return diags
+}
+
+func EncodeBrewing(brewing *structs.Brewing) (*Brewing, diag.Diagnostics) {
+	return encodeBrewing(path.Empty(), brewing)
+}
+
+func encodeBrewing(path path.Path, brewing *structs.Brewing) (*Brewing, diag.Diagnostics) {
+	if brewing == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	res := Brewing{}
+	res.StartedAt = timetypes.NewRFC3339TimeValue(brewing.StartedAt)
+	res.FinishedAt = timetypes.NewRFC3339TimePointerValue(brewing.FinishedAt)
+	res.Duration = timetypes.NewGoDurationValue(brewing.Duration)
+	if brewing.Steps != nil {
+		res.Steps = make([]timetypes.GoDuration, len(brewing.Steps))
+		for i, attr := range brewing.Steps {
+			res.Steps[i] = timetypes.NewGoDurationValue(attr)
+		}
+	}
+	res.Notes = types.StringValue(brewing.Notes)
+	return &res, diags
+}
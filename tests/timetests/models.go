@@ -0,0 +1,20 @@
+/*
+Code generated by github-terraform-generator; DO NOT EDIT.
+Any modifications will be overwritten
+*/
+// Generator: models
+
+package timetests
+
+import (
+	timetypes "github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	types "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Brewing struct {
+	StartedAt  timetypes.RFC3339      `tfsdk:"started_at"`
+	FinishedAt timetypes.RFC3339      `tfsdk:"finished_at"`
+	Duration   timetypes.GoDuration   `tfsdk:"duration"`
+	Steps      []timetypes.GoDuration `tfsdk:"steps"`
+	Notes      types.String           `tfsdk:"notes"`
+}
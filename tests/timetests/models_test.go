@@ -0,0 +1,85 @@
+package timetests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	structs "github.com/Lenstra/terraform-plugin-generator/tests/structs"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	finished := time.Date(2024, 1, 1, 10, 4, 0, 0, time.UTC)
+	brewing := &structs.Brewing{
+		StartedAt:  time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		FinishedAt: &finished,
+		Duration:   4 * time.Minute,
+		Steps:      []time.Duration{time.Minute, 3 * time.Minute},
+	}
+
+	s := brewingSchema()
+	state := &tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+	diags := Set(ctx, state, brewing)
+	require.False(t, diags.HasError(), diags)
+
+	var roundTrip *structs.Brewing
+	diags = Decode(ctx, state, &roundTrip)
+	require.False(t, diags.HasError(), diags)
+
+	require.Equal(t, brewing, roundTrip)
+}
+
+func TestSemanticEquality(t *testing.T) {
+	ctx := context.Background()
+	data, diags := EncodeBrewing(&structs.Brewing{
+		StartedAt: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		Duration:  time.Hour,
+	})
+	require.False(t, diags.HasError())
+
+	equal, diags := data.StartedAt.StringSemanticEquals(ctx, timetypes.NewRFC3339ValueMust("2024-01-01T10:00:00+00:00"))
+	require.False(t, diags.HasError())
+	require.True(t, equal)
+
+	equal, diags = data.Duration.StringSemanticEquals(ctx, timetypes.NewGoDurationValueFromStringMust("60m"))
+	require.False(t, diags.HasError())
+	require.True(t, equal)
+
+	// The values are decoded from any of their representations
+	data.Duration = timetypes.NewGoDurationValueFromStringMust("60m")
+	var roundTrip *structs.Brewing
+	diags = decodeBrewing(path.Empty(), data, &roundTrip)
+	require.False(t, diags.HasError())
+	require.Equal(t, time.Hour, roundTrip.Duration)
+
+	// The values that cannot be read are reported and not assigned
+	data.StartedAt = timetypes.NewRFC3339Unknown()
+	data.Duration = timetypes.NewGoDurationUnknown()
+	diags = decodeBrewing(path.Empty(), data, &roundTrip)
+	require.True(t, diags.HasError())
+	require.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), roundTrip.StartedAt)
+	require.Equal(t, time.Hour, roundTrip.Duration)
+}
+
+func TestEmptyDefault(t *testing.T) {
+	// An empty default is a value, the attribute is not left null
+	attribute := brewingSchema().Attributes["notes"].(schema.StringAttribute)
+	require.True(t, attribute.Computed)
+	require.NotNil(t, attribute.Default)
+	resp := &defaults.StringResponse{}
+	attribute.Default.DefaultString(context.Background(), defaults.StringRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError())
+	require.False(t, resp.PlanValue.IsNull())
+	require.Equal(t, "", resp.PlanValue.ValueString())
+}
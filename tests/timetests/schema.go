@@ -0,0 +1,45 @@
+/*
+Code generated by github-terraform-generator; DO NOT EDIT.
+Any modifications will be overwritten
+*/
+// Generator: resource schema
+
+package timetests
+
+import (
+	timetypes "github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	stringdefault "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+)
+
+func brewingSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Brewing is rendered with the custom types of terraform-plugin-framework-timetypes.",
+		Attributes: map[string]schema.Attribute{
+			"started_at": schema.StringAttribute{
+				Required:   true,
+				CustomType: timetypes.RFC3339Type{},
+			},
+			"finished_at": schema.StringAttribute{
+				Optional:   true,
+				CustomType: timetypes.RFC3339Type{},
+			},
+			"duration": schema.StringAttribute{
+				Optional:   true,
+				Computed:   true,
+				CustomType: timetypes.GoDurationType{},
+				Default:    stringdefault.StaticString("4m"),
+			},
+			"steps": schema.ListAttribute{
+				ElementType: timetypes.GoDurationType{},
+				Optional:    true,
+			},
+			"notes": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+		},
+		Blocks: map[string]schema.Block{},
+	}
+}
@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/dave/jennifer/jen"
+)
+
+const timetypesImportPath = "github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+
+// RFC3339Converter knows how to convert time.Time and *time.Time to the
+// timetypes.RFC3339 custom type of terraform-plugin-framework-timetypes, the
+// equivalent representations of a timestamp, e.g. Z and +00:00, do not
+// produce a diff
+type RFC3339Converter struct {
+	timeTypesConverter
+}
+
+var _ AttributeConverter = &RFC3339Converter{}
+
+func (c *RFC3339Converter) Check(typ GoType) (bool, error) {
+	return timeTypeName(typ) == "RFC3339", nil
+}
+
+func (c *RFC3339Converter) GetType() *jen.Statement {
+	return jen.Qual(timetypesImportPath, "RFC3339Type").Values()
+}
+
+// GoDurationConverter knows how to convert time.Duration and *time.Duration
+// to the timetypes.GoDuration custom type of
+// terraform-plugin-framework-timetypes, 1h and 60m do not produce a diff
+type GoDurationConverter struct {
+	timeTypesConverter
+}
+
+var _ AttributeConverter = &GoDurationConverter{}
+
+func (c *GoDurationConverter) Check(typ GoType) (bool, error) {
+	return timeTypeName(typ) == "GoDuration", nil
+}
+
+func (c *GoDurationConverter) GetType() *jen.Statement {
+	return jen.Qual(timetypesImportPath, "GoDurationType").Values()
+}
+
+// TimeTypesConverters are not part of the DefaultConverters, they must be
+// given before them to replace the StringConverter for the times and the
+// durations:
+//
+//	AttributeConverters: slices.Concat(TimeTypesConverters, DefaultConverters)
+var TimeTypesConverters = []AttributeConverter{
+	&RFC3339Converter{},
+	&GoDurationConverter{},
+}
+
+// timeTypesConverter implements the methods shared by RFC3339Converter and
+// GoDurationConverter
+type timeTypesConverter struct{}
+
+// timeTypeName returns the name of the timetypes type used for typ, it is
+// empty when typ is not supported
+func timeTypeName(typ GoType) string {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch {
+	case isNamedType(typ, "time", "Time"):
+		return "RFC3339"
+	case isNamedType(typ, "time", "Duration"):
+		return "GoDuration"
+	}
+	return ""
+}
+
+func (c *timeTypesConverter) GetFrameworkType(_ *Converter, typ GoType) (*jen.Statement, error) {
+	return jen.Qual(timetypesImportPath, timeTypeName(typ)), nil
+}
+
+func (c *timeTypesConverter) Decode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	op := jen.Empty()
+	if typ.Kind() == reflect.Pointer {
+		op = jen.Op("&")
+	}
+
+	ident, method := "t", "ValueRFC3339Time"
+	if timeTypeName(typ) == "GoDuration" {
+		ident, method = "dur", "ValueGoDuration"
+	}
+
+	return jen.If().Op("!").Add(src.Clone()).Dot("IsNull").Call().Block(
+		jen.List(jen.Id(ident), jen.Id("d")).Op(":=").Add(src.Clone()).Dot(method).Call(),
+		jen.Id("diags").Dot("Append").Call(jen.Id("d").Op("...")),
+		jen.If(jen.Op("!").Id("d").Dot("HasError").Call()).Block(
+			target.Op("=").Add(op).Id(ident),
+		),
+	), nil
+}
+
+func (c *timeTypesConverter) Encode(converters *Converter, field *FieldInformation, path, src, target *jen.Statement, typ GoType) (*jen.Statement, error) {
+	function := "NewRFC3339TimeValue"
+	if timeTypeName(typ) == "GoDuration" {
+		function = "NewGoDurationValue"
+	}
+	if typ.Kind() == reflect.Pointer {
+		function = function[:len(function)-len("Value")] + "PointerValue"
+	}
+	return target.Op("=").Qual(timetypesImportPath, function).Call(src), nil
+}
+
+func (c *timeTypesConverter) GetSchema(converters *Converter, path string, info *FieldInformation) (*jen.Statement, *jen.Statement, error) {
+	customType := jen.Id("CustomType").Op(":").Add(c.getType(info.goType))
+	return basicSchema(converters.SchemaImportPath(), "StringAttribute", info, []jen.Code{customType})
+}
+
+func (c *timeTypesConverter) getType(typ GoType) *jen.Statement {
+	return jen.Qual(timetypesImportPath, timeTypeName(typ)+"Type").Values()
+}
+
+func (c *timeTypesConverter) GetAttrType(_ *Converter, info *FieldInformation, typ GoType) (*jen.Statement, error) {
+	return c.getType(typ), nil
+}
+
+func (c *timeTypesConverter) GetValue(_ *Converter, _ *FieldInformation, typ GoType, value interface{}) (*jen.Statement, error) {
+	name := timeTypeName(typ)
+	if value == nil {
+		return jen.Qual(timetypesImportPath, "New"+name+"Null").Call(), nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a string, got %T", value)
+	}
+	if err := checkString(typ, s); err != nil {
+		return nil, err
+	}
+	function := "NewRFC3339ValueMust"
+	if name == "GoDuration" {
+		function = "NewGoDurationValueFromStringMust"
+	}
+	return jen.Qual(timetypesImportPath, function).Call(jen.Lit(s)), nil
+}
+
+func (c *timeTypesConverter) GetDefault(converters *Converter, info *FieldInformation, literal string) (*jen.Statement, error) {
+	// The framework converts the default values to the custom type of the
+	// attribute
+	return (&StringConverter{}).GetDefault(converters, info, literal)
+}
+
+func (c *timeTypesConverter) GetAttributeKind(_ *FieldInformation) string {
+	return "String"
+}
+
+func (c *timeTypesConverter) GetValidators(converters *Converter, info *FieldInformation, constraints []Constraint) ([]jen.Code, error) {
+	return (&StringConverter{}).GetValidators(converters, info, constraints)
+}
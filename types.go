@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"reflect"
+)
+
+// GoType is the Go type of a field as seen by the converters. It is either
+// backed by reflect, see TypeOf, or by go/types when the types are loaded from
+// their source, see SourceLoader. The types returned by an implementation can
+// be compared with ==.
+type GoType interface {
+	Kind() reflect.Kind
+	// Name returns the name of a defined type, it is empty for the other
+	// types
+	Name() string
+	// PkgPath returns the import path of the package of a defined type, it is
+	// empty for the predeclared and the other types
+	PkgPath() string
+	String() string
+	// Bits returns the size in bits of the numeric types
+	Bits() int
+	// Elem returns the element type of an array, a map, a pointer or a slice
+	Elem() GoType
+	// Key returns the key type of a map
+	Key() GoType
+	// NumMethod returns the number of exported methods, or the number of
+	// methods of an interface
+	NumMethod() int
+	NumField() int
+	Field(int) StructField
+}
+
+// StructField is a field of a struct GoType
+type StructField struct {
+	Name string
+	// PkgPath is the import path of the package of the unexported fields
+	PkgPath   string
+	Type      GoType
+	Tag       reflect.StructTag
+	Anonymous bool
+}
+
+func (f StructField) IsExported() bool {
+	return f.PkgPath == ""
+}
+
+// deprecatedType is implemented by the types that can find whether they
+// implement DeprecatedType
+type deprecatedType interface {
+	deprecationMessage() (string, error)
+}
+
+// sliceType is implemented by the types that can build a slice of themselves
+type sliceType interface {
+	sliceOf() GoType
+}
+
+// TypeOf returns the GoType of v, v can also be a GoType, e.g. one returned by a
+// SourceLoader, in which case it is returned as is
+func TypeOf(v interface{}) GoType {
+	if typ, ok := v.(GoType); ok {
+		return typ
+	}
+	return reflectTypeOf(reflect.TypeOf(v))
+}
+
+// SliceOf returns the type of the slices of typ
+func SliceOf(typ GoType) GoType {
+	return typ.(sliceType).sliceOf()
+}
+
+// isNamedType returns whether typ is the type name of the package pkgPath
+func isNamedType(typ GoType, pkgPath, name string) bool {
+	return typ.PkgPath() == pkgPath && typ.Name() == name
+}
+
+// isEmptyInterface returns whether typ is interface{}
+func isEmptyInterface(typ GoType) bool {
+	return typ.Kind() == reflect.Interface && typ.Name() == "" && typ.NumMethod() == 0
+}
+
+// reflectType is the GoType backed by reflect
+type reflectType struct {
+	typ reflect.Type
+}
+
+var _ GoType = reflectType{}
+
+func reflectTypeOf(typ reflect.Type) GoType {
+	if typ == nil {
+		return nil
+	}
+	return reflectType{typ: typ}
+}
+
+func (t reflectType) Kind() reflect.Kind { return t.typ.Kind() }
+func (t reflectType) Name() string       { return t.typ.Name() }
+func (t reflectType) PkgPath() string    { return t.typ.PkgPath() }
+func (t reflectType) String() string     { return t.typ.String() }
+func (t reflectType) Bits() int          { return t.typ.Bits() }
+func (t reflectType) Elem() GoType       { return reflectTypeOf(t.typ.Elem()) }
+func (t reflectType) Key() GoType        { return reflectTypeOf(t.typ.Key()) }
+func (t reflectType) NumMethod() int     { return t.typ.NumMethod() }
+func (t reflectType) NumField() int      { return t.typ.NumField() }
+
+func (t reflectType) Field(i int) StructField {
+	field := t.typ.Field(i)
+	return StructField{
+		Name:      field.Name,
+		PkgPath:   field.PkgPath,
+		Type:      reflectTypeOf(field.Type),
+		Tag:       field.Tag,
+		Anonymous: field.Anonymous,
+	}
+}
+
+func (t reflectType) sliceOf() GoType {
+	return reflectTypeOf(reflect.SliceOf(t.typ))
+}
+
+func (t reflectType) deprecationMessage() (string, error) {
+	typ := t.typ
+	if typ.Kind() != reflect.Pointer {
+		typ = reflect.PointerTo(typ)
+	}
+	if typ.Implements(reflect.TypeOf((*DeprecatedType)(nil)).Elem()) {
+		return reflect.New(typ.Elem()).Interface().(DeprecatedType).DeprecationMessage(), nil
+	}
+	return "", nil
+}
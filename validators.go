@@ -0,0 +1,280 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+const (
+	validatorImportPath  = "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	validatorsImportPath = "github.com/hashicorp/terraform-plugin-framework-validators/"
+)
+
+// Constraint is a validation modifier found in the tag, e.g. min=1
+type Constraint struct {
+	Name  string
+	Value string
+}
+
+// ValidatorConverter is implemented by the attribute converters that support
+// the validation modifiers
+type ValidatorConverter interface {
+	AttributeKindConverter
+
+	// GetValidators returns the validators for the constraints given in the
+	// tag, it must return an error for the constraints that are not
+	// supported by the attribute type
+	GetValidators(*Converter, *FieldInformation, []Constraint) ([]jen.Code, error)
+}
+
+// crossValidators maps the modifiers referencing other attributes to the
+// functions of the validators packages
+var crossValidators = map[string]string{
+	"conflicts_with":  "ConflictsWith",
+	"exactly_one_of":  "ExactlyOneOf",
+	"at_least_one_of": "AtLeastOneOf",
+	"also_requires":   "AlsoRequires",
+}
+
+// attributeReference is an attribute referenced by a cross-attribute
+// validator, it is checked once the whole schema is known
+type attributeReference struct {
+	modifier string
+	from     string
+	target   string
+	through  []string
+}
+
+// resolveValidators converts the constraints found in the tag to the
+// framework validators using the converter of the field, the converters can
+// also add validators when there are none, e.g. for the bounds of the integers
+func (c *Converter) resolveValidators(info *FieldInformation) error {
+	constraints := []Constraint{}
+	references := []Constraint{}
+	for _, constraint := range info.Constraints {
+		if _, found := crossValidators[constraint.Name]; found {
+			references = append(references, constraint)
+		} else {
+			constraints = append(constraints, constraint)
+		}
+	}
+
+	converter, err := c.Get(info.goType)
+	if err != nil {
+		return err
+	}
+	validatorConverter, ok := converter.(ValidatorConverter)
+	if !ok {
+		if len(info.Constraints) == 0 {
+			return nil
+		}
+		return fmt.Errorf("%s: %T does not support validators", info.Path, converter)
+	}
+
+	codes, err := validatorConverter.GetValidators(c, info, constraints)
+	if err != nil {
+		return fmt.Errorf("%s: %w", info.Path, err)
+	}
+
+	kind := validatorConverter.GetAttributeKind(info)
+	for _, constraint := range references {
+		expressions := []jen.Code{}
+		for _, ref := range strings.Split(constraint.Value, "|") {
+			expression, err := c.resolveReference(constraint.Name, info.Path, ref)
+			if err != nil {
+				return fmt.Errorf("%s: %w", info.Path, err)
+			}
+			expressions = append(expressions, expression)
+		}
+		codes = append(codes, jen.Qual(validatorPackage(kind), crossValidators[constraint.Name]).Call(expressions...))
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+
+	if info.Validators != nil {
+		// The validators set by the FieldInformationGetter are kept, they are
+		// converted since they can be an untyped nil
+		existing := info.Validators
+		info.Validators = jen.AppendFunc(func(g *jen.Group) {
+			g.Index().Qual(validatorImportPath, kind).Parens(existing)
+			for _, code := range codes {
+				g.Line().Add(code)
+			}
+			g.Line()
+		})
+		return nil
+	}
+	info.Validators = jen.Index().Qual(validatorImportPath, kind).ValuesFunc(func(g *jen.Group) {
+		for _, code := range codes {
+			g.Line().Add(code)
+		}
+		g.Line()
+	})
+	return nil
+}
+
+// configurable returns whether the value of the attribute is given by the
+// user, only the lookup keys are in the schemas derived from a resource since
+// dataSourceField makes the other attributes computed
+func (c *Converter) configurable(info *FieldInformation) bool {
+	return info.Required || info.Optional
+}
+
+// resolveReference returns the path expression of the attribute referenced by
+// ref from the attribute at fieldPath. The reference is the name of a sibling
+// attribute, it can be prefixed by "../" to reference the attributes of the
+// parent object and use "." to reference a nested attribute.
+func (c *Converter) resolveReference(modifier, fieldPath, ref string) (*jen.Statement, error) {
+	expression := jen.Qual("github.com/hashicorp/terraform-plugin-framework/path", "MatchRelative").Call().Dot("AtParent").Call()
+	parent := fieldPath[:strings.LastIndex(fieldPath, ".")]
+
+	name := ref
+	for strings.HasPrefix(name, "../") {
+		name = strings.TrimPrefix(name, "../")
+		i := strings.LastIndex(parent, ".")
+		if i == -1 {
+			return nil, fmt.Errorf("%s references %q which is outside of the schema", modifier, ref)
+		}
+		// The elements of lists, sets and maps add a step in the path
+		if c.attributePaths[parent] {
+			expression = expression.Dot("AtParent").Call()
+		}
+		expression = expression.Dot("AtParent").Call()
+		parent = parent[:i]
+	}
+
+	target := parent
+	through := []string{}
+	for _, name := range strings.Split(name, ".") {
+		if name == "" || name == ".." {
+			return nil, fmt.Errorf("%s has an invalid reference %q", modifier, ref)
+		}
+		if target != parent {
+			through = append(through, target)
+		}
+		target += "." + name
+		expression = expression.Dot("AtName").Call(jen.Lit(name))
+	}
+
+	c.references = append(c.references, attributeReference{
+		modifier: modifier,
+		from:     fieldPath,
+		target:   target,
+		through:  through,
+	})
+	return expression, nil
+}
+
+// checkReferences makes sure that the attributes referenced by the
+// cross-attribute validators exist, it must be called once the whole schema
+// has been rendered
+func (c *Converter) checkReferences() error {
+	for _, ref := range c.references {
+		if _, found := c.attributePaths[ref.target]; !found {
+			return fmt.Errorf("%s: %s references unknown attribute %q", ref.from, ref.modifier, ref.target)
+		}
+		for _, p := range ref.through {
+			if c.attributePaths[p] {
+				return fmt.Errorf("%s: %s cannot reference %q as it is nested in the collection %q", ref.from, ref.modifier, ref.target, p)
+			}
+		}
+	}
+	return nil
+}
+
+func validatorPackage(kind string) string {
+	return validatorsImportPath + strings.ToLower(kind) + "validator"
+}
+
+func unsupportedConstraint(constraint Constraint, kind string) error {
+	return fmt.Errorf("the %s modifier is not supported for %s attributes", constraint.Name, strings.ToLower(kind))
+}
+
+// boundValidators returns the validators checking that a value is between min
+// and max, either of them can be nil
+func boundValidators(importPath, atLeast, atMost, between string, min, max *jen.Statement) []jen.Code {
+	switch {
+	case min != nil && max != nil:
+		return []jen.Code{jen.Qual(importPath, between).Call(min, max)}
+	case min != nil:
+		return []jen.Code{jen.Qual(importPath, atLeast).Call(min)}
+	case max != nil:
+		return []jen.Code{jen.Qual(importPath, atMost).Call(max)}
+	}
+	return nil
+}
+
+// parseSize parses a non negative size used by the length, min_items and
+// max_items modifiers
+func parseSize(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("expected a positive size, got %d", n)
+	}
+	return n, nil
+}
+
+// parseRange parses the value of the length modifier, it can be "n", "min..",
+// "..max" or "min..max"
+func parseRange(value string) (*jen.Statement, *jen.Statement, error) {
+	lower, upper, found := strings.Cut(value, "..")
+	if !found {
+		upper = lower
+	}
+
+	var min, max *jen.Statement
+	var minValue, maxValue int
+	var err error
+	if lower != "" {
+		if minValue, err = parseSize(lower); err != nil {
+			return nil, nil, err
+		}
+		min = jen.Lit(minValue)
+	}
+	if upper != "" {
+		if maxValue, err = parseSize(upper); err != nil {
+			return nil, nil, err
+		}
+		max = jen.Lit(maxValue)
+	}
+	if min == nil && max == nil {
+		return nil, nil, fmt.Errorf("invalid range %q", value)
+	}
+	if min != nil && max != nil && minValue > maxValue {
+		return nil, nil, fmt.Errorf("invalid range %q, %d is greater than %d", value, minValue, maxValue)
+	}
+	return min, max, nil
+}
+
+// sizeValidators returns the validators for the min_items and max_items
+// modifiers of lists, sets and maps
+func sizeValidators(kind string, constraints []Constraint) ([]jen.Code, error) {
+	var min, max *jen.Statement
+	var minValue, maxValue int
+	for _, constraint := range constraints {
+		if constraint.Name != "min_items" && constraint.Name != "max_items" {
+			return nil, unsupportedConstraint(constraint, kind)
+		}
+		n, err := parseSize(constraint.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q: %w", constraint.Name, constraint.Value, err)
+		}
+
+		if constraint.Name == "min_items" {
+			min, minValue = jen.Lit(n), n
+		} else {
+			max, maxValue = jen.Lit(n), n
+		}
+	}
+	if min != nil && max != nil && minValue > maxValue {
+		return nil, fmt.Errorf("min_items %d is greater than max_items %d", minValue, maxValue)
+	}
+	return boundValidators(validatorPackage(kind), "SizeAtLeast", "SizeAtMost", "SizeBetween", min, max), nil
+}